@@ -0,0 +1,90 @@
+// Package atmodemtest provides a reusable in-process atmodem.Transport fake
+// for use in tests, replacing the ad hoc fakes historically hand-rolled by
+// atmodem's own test suite.
+package atmodemtest
+
+import "bytes"
+
+// A Fake is an in-process atmodem.Transport which replies OK to the
+// underlying AT library's escape/ATZ/ATE0 initialization sequence, then
+// replays a configured sequence of canned responses, one per subsequent
+// command. If more commands are issued than there are responses, the last
+// response is repeated.
+//
+// A Fake also supports Push, which delivers a line to the Device independent
+// of any command exchange, simulating an unsolicited result code.
+//
+// A Fake is only safe for use by a single Device at a time.
+type Fake struct {
+	responses []string
+
+	writes  int
+	written bytes.Buffer
+	resC    chan string
+	pushC   chan string
+}
+
+// New creates a Fake which replays responses in order, one per command
+// issued after Device initialization.
+func New(responses ...string) *Fake {
+	return &Fake{
+		responses: responses,
+		resC:      make(chan string),
+		pushC:     make(chan string, 16),
+	}
+}
+
+// Read implements atmodem.Transport.
+func (f *Fake) Read(b []byte) (int, error) {
+	// The underlying AT library reads continuously, so block until a
+	// response is queued by a corresponding Write, or a line is queued by
+	// Push, whichever comes first.
+	select {
+	case line := <-f.pushC:
+		return copy(b, []byte(line+"\r\n")), nil
+	case res := <-f.resC:
+		return copy(b, []byte(res+"\r\n")), nil
+	}
+}
+
+// Push delivers line to the Device as an unsolicited line from the modem,
+// independent of any outstanding or subsequent command exchange. It is used
+// to simulate a URC arriving with no prior command, which Write-driven
+// responses cannot represent.
+func (f *Fake) Push(line string) {
+	f.pushC <- line
+}
+
+// Write implements atmodem.Transport.
+func (f *Fake) Write(b []byte) (int, error) {
+	defer func() { f.writes++ }()
+
+	// The first three writes are the AT library's initialization sequence
+	// (escape, ATZ, ATE0), which always succeeds.
+	if f.writes < 3 {
+		f.resC <- "OK"
+		return len(b), nil
+	}
+
+	f.written.Write(b)
+
+	i := f.writes - 3
+	if i >= len(f.responses) {
+		i = len(f.responses) - 1
+	}
+
+	var res string
+	if i >= 0 {
+		res = f.responses[i]
+	}
+	f.resC <- res
+
+	return len(b), nil
+}
+
+// Close implements io.Closer, and is a no-op.
+func (f *Fake) Close() error { return nil }
+
+// Written returns the raw bytes written by the Device under test following
+// its initialization sequence.
+func (f *Fake) Written() []byte { return f.written.Bytes() }
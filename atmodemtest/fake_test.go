@@ -0,0 +1,40 @@
+package atmodemtest
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFakeReplaysResponsesInOrder(t *testing.T) {
+	f := New("first", "second")
+
+	// Consume the initialization sequence.
+	for i := 0; i < 3; i++ {
+		go func() { <-f.resC }()
+		if _, err := f.Write([]byte("init\r\n")); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+	}
+
+	for _, want := range []string{"first", "second", "second"} {
+		var got string
+		done := make(chan struct{})
+		go func() {
+			got = <-f.resC
+			close(done)
+		}()
+
+		if _, err := f.Write([]byte("AT\r\n")); err != nil {
+			t.Fatalf("failed to write: %v", err)
+		}
+		<-done
+
+		if got != want {
+			t.Fatalf("unexpected response: got %q, want %q", got, want)
+		}
+	}
+
+	if !bytes.Equal(f.Written(), bytes.Repeat([]byte("AT\r\n"), 3)) {
+		t.Fatalf("unexpected written bytes: %q", f.Written())
+	}
+}
@@ -1,20 +1,54 @@
 package atmodem
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tarm/serial"
 	atdevice "github.com/warthog618/modem/at"
 )
 
-// A Device is a modem which communicates using AT commands.
+// A Transport is the byte stream used to exchange AT commands with a modem.
+// It is satisfied by a serial port, a TCP-attached AT interface (many
+// industrial gateways expose AT over telnet on port 5000), or a fake used
+// in tests, such as atmodemtest.Fake. If a Transport also implements
+// io.Closer, its Close method will be called on Device.Close.
+type Transport interface {
+	io.ReadWriter
+}
+
+// A Device is a modem which communicates using AT commands. A Device is
+// safe for concurrent use: commands issued from multiple goroutines are
+// automatically serialized behind a single in-flight exchange.
 type Device struct {
-	rw io.ReadWriter
+	rw Transport
 	d  *atdevice.AT
+
+	reqC      chan *commandRequest
+	closeC    chan struct{}
+	closeOnce sync.Once
+
+	metrics MetricsSink
+	tracer  CommandTracer
+
+	// mu guards info and statusStrict below, which may be read and written
+	// directly by caller goroutines at any time. metrics and tracer above are
+	// not guarded: they are set by WithMetrics and WithCommandTracer and read
+	// without locking by every command.
+	mu sync.Mutex
+
+	// info caches the result of the first successful Info call, used to
+	// label metrics published by WithMetrics.
+	info *Info
+
+	// statusStrict controls whether Status returns ErrUnknownField for
+	// unrecognized response lines. See StatusStrict.
+	statusStrict bool
 }
 
 // Dial dials a serial connection to a modem with the specified port, baud rate,
@@ -32,23 +66,31 @@ func Dial(port string, baud int, timeout time.Duration) (*Device, error) {
 	return Open(p, timeout)
 }
 
-// Open opens a connection to a modem using an existing io.ReadWriter. If rw
-// also implements io.Closer, its Close method will be called on Device.Close.
-func Open(rw io.ReadWriter, timeout time.Duration) (*Device, error) {
-	d := atdevice.New(rw, atdevice.WithTimeout(timeout))
+// Open opens a connection to a modem using an existing Transport. If t also
+// implements io.Closer, its Close method will be called on Device.Close.
+func Open(t Transport, timeout time.Duration) (*Device, error) {
+	d := atdevice.New(t, atdevice.WithTimeout(timeout))
 	if err := d.Init(); err != nil {
 		return nil, err
 	}
 
-	return &Device{
-		rw: rw,
-		d:  d,
-	}, nil
+	dev := &Device{
+		rw:     t,
+		d:      d,
+		reqC:   make(chan *commandRequest),
+		closeC: make(chan struct{}),
+	}
+	go dev.serve()
+
+	return dev, nil
 }
 
-// Close closes the underlying io.ReadWriter if it also implements io.Closer,
-// or is a no-op otherwise.
+// Close closes the underlying Transport if it also implements io.Closer, or
+// is a no-op otherwise. It also stops the goroutine that serializes
+// commands; any Command call still queued or in flight returns an error.
 func (d *Device) Close() error {
+	d.closeOnce.Do(func() { close(d.closeC) })
+
 	c, ok := d.rw.(io.Closer)
 	if !ok {
 		return nil
@@ -57,6 +99,86 @@ func (d *Device) Close() error {
 	return c.Close()
 }
 
+// A commandRequest queues a single AT command exchange to be run on
+// Device.serve.
+type commandRequest struct {
+	cmd  string
+	opts []atdevice.CommandOption
+	resC chan commandResult
+}
+
+// A commandResult is the outcome of a commandRequest.
+type commandResult struct {
+	resp []string
+	err  error
+}
+
+// serve runs on its own goroutine for the lifetime of a Device, issuing
+// commands to the underlying atdevice.AT one at a time so that concurrent
+// callers are safely queued behind a single in-flight exchange.
+func (d *Device) serve() {
+	for {
+		select {
+		case req := <-d.reqC:
+			resp, err := d.d.Command(req.cmd, req.opts...)
+			req.resC <- commandResult{resp: resp, err: err}
+		case <-d.closeC:
+			return
+		}
+	}
+}
+
+// Command issues cmd to the modem and returns its response lines, honoring
+// ctx cancellation and, if ctx has a deadline, applying it to the
+// underlying exchange via atdevice.WithTimeout so a hung command doesn't
+// occupy the queue indefinitely.
+//
+// If ctx is cancelled while cmd is still queued, Command returns ctx.Err()
+// without issuing it. If ctx is cancelled after cmd was issued but before the
+// modem replies, Command returns ctx.Err() and best-effort writes the AT
+// abort sequence (ESC) to the Transport to ask the modem to stop processing
+// the command. If ctx carries a deadline, the underlying exchange is bounded
+// by it and will itself time out around the same time. But if ctx is only
+// cancelled (via context.WithCancel, with no deadline), the underlying
+// exchange is bounded only by the timeout passed to Open: it may still
+// complete in the background and occupy the queue, blocking subsequent
+// Command calls, until it completes or that timeout expires.
+func (d *Device) Command(ctx context.Context, cmd string) ([]string, error) {
+	return d.command(ctx, cmd)
+}
+
+// exec queues cmd on serve and waits for its result or for ctx to be
+// cancelled.
+func (d *Device) exec(ctx context.Context, cmd string) ([]string, error) {
+	var opts []atdevice.CommandOption
+	if dl, ok := ctx.Deadline(); ok {
+		opts = append(opts, atdevice.WithTimeout(time.Until(dl)))
+	}
+
+	req := &commandRequest{cmd: cmd, opts: opts, resC: make(chan commandResult, 1)}
+
+	select {
+	case d.reqC <- req:
+	case <-d.closeC:
+		return nil, errors.New("atmodem: device is closed")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case res := <-req.resC:
+		return res.resp, res.err
+	case <-d.closeC:
+		return nil, errors.New("atmodem: device is closed")
+	case <-ctx.Done():
+		// Best-effort: the transport write races with serve's use of the
+		// same command, so this cannot guarantee the in-flight command is
+		// actually interrupted.
+		_, _ = d.rw.Write([]byte{0x1b})
+		return nil, ctx.Err()
+	}
+}
+
 // Info contains device information about a modem.
 type Info struct {
 	Manufacturer, Model, Revision, IMEI, MEID, FSN string
@@ -65,8 +187,8 @@ type Info struct {
 }
 
 // Info requests device information from the modem.
-func (d *Device) Info() (*Info, error) {
-	ss, err := d.d.Command("I")
+func (d *Device) Info(ctx context.Context) (*Info, error) {
+	ss, err := d.command(ctx, "I")
 	if err != nil {
 		return nil, err
 	}
@@ -118,186 +240,3 @@ func parseInfo(lines []string) (*Info, error) {
 
 	return &i, nil
 }
-
-// Status contains the modem's current radio status.
-type Status struct {
-	CurrentTime                                 time.Duration
-	Temperature, ResetCounter                   int
-	Mode, SystemMode, PSState, LTEBand          string
-	LTEBandwidthMHz                             float64
-	LTEReceiveChannel, LTETransmitChannel       int
-	LTECAState, EMMState, RRCState, IMSRegState string
-	PCCRXMRSSI, RSRPRXMdBm                      int
-	PCCRXDRSSI, RSRPRXDdBm                      int
-	TransmitPower                               int
-	TAC, CellID                                 string
-	RSRQdB, SINRdB                              float64
-
-	state statusState
-}
-
-// statusState stores temporary state while parsing Status fields.
-type statusState int
-
-// Possible statusState values which indicate alternative parsing code paths.
-const (
-	_ statusState = iota
-	rxmLast
-	rxdLast
-)
-
-// Status returns the current status of the modem.
-func (d *Device) Status() (*Status, error) {
-	ss, err := d.d.Command("!GSTATUS?")
-	if err != nil {
-		return nil, err
-	}
-	if len(ss) == 0 {
-		return nil, errors.New("atmodem: empty status response from modem")
-	}
-
-	return parseStatus(ss)
-}
-
-// parseStatus unpacks a Status structure from a modem response.
-func parseStatus(lines []string) (*Status, error) {
-	// TODO: consider a regex based parsing approach if it turns out the format
-	// is more complex than anticipated.
-	//
-	// Example: https://regex101.com/r/DS6IIk/5, thanks @cockeys!
-	var s Status
-	for i, l := range lines {
-		if i == 0 {
-			// Skip the !GSTATUS: response header.
-			continue
-		}
-
-		// For each line, determine the number of key/value pairs by checking
-		// for colons and keeping track of their positions.
-		var indices []int
-		ss := strings.Fields(l)
-		for i, s := range ss {
-			if strings.HasSuffix(s, ":") {
-				indices = append(indices, i)
-			}
-		}
-
-		switch len(indices) {
-		case 1:
-			// Single key/value pair on one line, parse as-is.
-			if err := s.parse(ss); err != nil {
-				return nil, err
-			}
-		case 2:
-			// Multiple key/value pairs on one line, assume that the format is:
-			// "foo foo: bar    baz baz: qux corge"
-			//
-			// It seems that the first key/value pair on a line always has a
-			// single value without any spaces, likely for ease of parsing
-			// in another program.
-			//
-			// The second key/value pair can contain one or more words until
-			// the end of the line.
-			next := indices[0] + 2
-			if err := s.parse(ss[:next]); err != nil {
-				return nil, err
-			}
-			if err := s.parse(ss[next:]); err != nil {
-				return nil, err
-			}
-		default:
-			// We only handle lines with one or two key/value pairs.
-			return nil, fmt.Errorf("atmodem: unexpected status response line with %d key/value pairs %q", len(indices), l)
-		}
-	}
-
-	return &s, nil
-}
-
-// parse parses a key/value pair string slice into a field of Status.
-func (s *Status) parse(ss []string) error {
-	for i := range ss {
-		if !strings.HasSuffix(ss[i], ":") {
-			// Not a key/value pair.
-			continue
-		}
-
-		// Advance the cursor and interpret the key/value pair as a string key
-		// and slice of fields which may be parsed in different ways.
-		i++
-		k := strings.Join(ss[:i], " ")
-
-		vp, err := newValueParser(ss[i:])
-		if err != nil {
-			return err
-		}
-
-		switch k {
-		case "Current Time:":
-			s.CurrentTime = time.Duration(vp.Int()) * time.Second
-		case "Temperature:":
-			s.Temperature = vp.Int()
-		case "Reset Counter:":
-			s.ResetCounter = vp.Int()
-		case "Mode:":
-			s.Mode = vp.String()
-		case "System mode:":
-			s.SystemMode = vp.String()
-		case "PS state:":
-			s.PSState = vp.String()
-		case "LTE band:":
-			s.LTEBand = vp.String()
-		case "LTE bw:":
-			s.LTEBandwidthMHz = vp.Float64()
-		case "LTE Rx chan:":
-			s.LTEReceiveChannel = vp.Int()
-		case "LTE Tx chan:":
-			s.LTETransmitChannel = vp.Int()
-		case "LTE CA state:":
-			s.LTECAState = vp.String()
-		case "EMM state:":
-			// TODO: consider parsing as state and substate fields.
-			s.EMMState = vp.String()
-		case "RRC state:":
-			s.RRCState = vp.String()
-		case "IMS reg state:":
-			s.IMSRegState = vp.String()
-		case "PCC RxM RSSI:":
-			s.PCCRXMRSSI = vp.Int()
-			s.state = rxmLast
-		case "PCC RxD RSSI:":
-			s.PCCRXDRSSI = vp.Int()
-			s.state = rxdLast
-		case "RSRP (dBm):":
-			// This key is reused for multiple fields, so the value is parsed
-			// into different struct fields depending on the previous parser
-			// state.
-			switch s.state {
-			case rxmLast:
-				s.RSRPRXMdBm = vp.Int()
-			case rxdLast:
-				s.RSRPRXDdBm = vp.Int()
-			default:
-				return fmt.Errorf("atmodem: cannot determine which RSRP dBm value is being parsed")
-			}
-		case "Tx Power:":
-			s.TransmitPower = vp.Int()
-		case "TAC:":
-			s.TAC = vp.String()
-		case "Cell ID:":
-			s.CellID = vp.String()
-		case "RSRQ (dB):":
-			s.RSRQdB = vp.Float64()
-		case "SINR (dB):":
-			s.SINRdB = vp.Float64()
-		default:
-			// TODO!
-		}
-
-		if err := vp.Err(); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
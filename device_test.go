@@ -1,15 +1,16 @@
 package atmodem_test
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
-	"io"
-	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/mdlayher/atmodem"
+	"github.com/mdlayher/atmodem/atmodemtest"
 )
 
 func TestDeviceInfo(t *testing.T) {
@@ -81,7 +82,7 @@ OK`,
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			withDevice(t, tt.r, tt.ok, []byte("ATI\r\n"), func(d *atmodem.Device) error {
-				info, err := d.Info()
+				info, err := d.Info(context.Background())
 				if err != nil {
 					return err
 				}
@@ -96,17 +97,14 @@ OK`,
 	}
 }
 
+// withDevice opens a Device using an atmodemtest.Fake which replies res to
+// the first command issued after initialization, then checks that fn's
+// Device issued exactly commands as its only post-initialization write.
 func withDevice(t *testing.T, res string, ok bool, commands []byte, fn func(d *atmodem.Device) error) {
 	t.Helper()
 
-	// Open a device using a simulated read/write/closer which returns
-	// user input and captures command output.
-	buf := bytes.NewBuffer(nil)
-	d, err := atmodem.Open(&readWriteCloser{
-		r:    strings.TrimSpace(res),
-		w:    buf,
-		resC: make(chan string),
-	}, 1*time.Second)
+	fake := atmodemtest.New(res)
+	d, err := atmodem.Open(fake, 1*time.Second)
 	if err != nil {
 		t.Fatalf("failed to open device: %v", err)
 	}
@@ -124,7 +122,7 @@ func withDevice(t *testing.T, res string, ok bool, commands []byte, fn func(d *a
 		return
 	}
 
-	if diff := cmp.Diff(commands, buf.Bytes()); diff != "" {
+	if diff := cmp.Diff(commands, fake.Written()); diff != "" {
 		t.Fatalf("unexpected modem commands (-want +got):\n%s", diff)
 	}
 }
@@ -141,20 +139,14 @@ func TestDeviceStatus(t *testing.T) {
 			r:    "OK",
 		},
 		{
-			name: "malformed no key/values",
+			name: "unrecognized line ignored in best-effort mode",
 			r: `
 !GSTATUS:
 foo
 
 OK`,
-		},
-		{
-			name: "malformed too many key/values",
-			r: `
-!GSTATUS:
-foo: bar bar: baz baz: qux
-
-OK`,
+			status: &atmodem.Status{},
+			ok:     true,
 		},
 		{
 			name: "OK MC7455",
@@ -179,9 +171,31 @@ SINR (dB):      0.6
 
 OK`,
 			status: &atmodem.Status{
-				CurrentTime: 19*time.Hour + 51*time.Minute + 5*time.Second,
-				Temperature: 41,
-				// TODO!
+				CurrentTime:        19*time.Hour + 51*time.Minute + 5*time.Second,
+				Temperature:        41,
+				ResetCounter:       8,
+				Mode:               "ONLINE",
+				SystemMode:         "LTE",
+				PSState:            "Attached",
+				LTEBand:            "B12",
+				LTEBandwidthMHz:    5,
+				LTEReceiveChannel:  5035,
+				LTETransmitChannel: 23035,
+				LTECAState:         "NOT ASSIGNED",
+				EMMState:           "Registered",
+				EMMSubstate:        "Normal Service",
+				RRCState:           "RRC Idle",
+				IMSRegState:        "No Srv",
+				PCCRXMRSSI:         -84,
+				RSRPRXMdBm:         -113,
+				PCCRXDRSSI:         -84,
+				RSRPRXDdBm:         -111,
+				TAC:                "BEEF",
+				TACDecimal:         12345,
+				CellID:             "DEADBEEF",
+				CellIDDecimal:      1234567,
+				RSRQdB:             -13.5,
+				SINRdB:             0.6,
 			},
 			ok: true,
 		},
@@ -190,7 +204,7 @@ OK`,
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			withDevice(t, tt.r, tt.ok, []byte("AT!GSTATUS?\r\n"), func(d *atmodem.Device) error {
-				status, err := d.Status()
+				status, err := d.Status(context.Background())
 				if err != nil {
 					return err
 				}
@@ -205,59 +219,135 @@ OK`,
 	}
 }
 
-var _ io.ReadWriteCloser = &readWriteCloser{}
+func TestDeviceStatusStrict(t *testing.T) {
+	withDevice(t, `
+!GSTATUS:
+foo
+
+OK`, false, []byte("AT!GSTATUS?\r\n"), func(d *atmodem.Device) error {
+		d.StatusStrict(true)
 
-type readWriteCloser struct {
-	r      string
-	w      *bytes.Buffer
-	writes int
+		_, err := d.Status(context.Background())
+		if !errors.Is(err, atmodem.ErrUnknownField) {
+			return fmt.Errorf("expected ErrUnknownField, got: %v", err)
+		}
 
-	resC chan string
+		return err
+	})
 }
 
-func (rw *readWriteCloser) Read(b []byte) (int, error) {
-	// The at package reads continuously so block until a response is sent due
-	// to an incoming write.
-	n := copy(b, []byte(<-rw.resC+"\r\n"))
-	return n, nil
+// TestDeviceConcurrentCommands verifies that Device, documented as safe for
+// concurrent use, serializes Command calls issued from multiple goroutines
+// behind its single in-flight exchange rather than racing the Transport.
+// Run with -race to catch any unsynchronized access.
+func TestDeviceConcurrentCommands(t *testing.T) {
+	const n = 20
+
+	fake := atmodemtest.New("OK")
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, err := d.Command(context.Background(), "I"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error from concurrent Command: %v", err)
+	}
+
+	if want, got := n*len("ATI\r\n"), len(fake.Written()); want != got {
+		t.Fatalf("unexpected total bytes written: want %d, got %d", want, got)
+	}
 }
 
-func (rw *readWriteCloser) Write(b []byte) (int, error) {
-	defer func() { rw.writes++ }()
+// TestDeviceConcurrentStatus verifies that Status and StatusStrict, which
+// read and write Device state (info, statusStrict) outside of serve, are
+// safe to call concurrently from multiple goroutines. Run with -race to
+// catch any unsynchronized access.
+func TestDeviceConcurrentStatus(t *testing.T) {
+	const n = 20
 
-	// Consume the modem init messages and return an appropriate response if
-	// necessary.
-	switch rw.writes {
-	case 0:
-		if !bytes.Equal(b, []byte("\x1b\r\n\r\n")) {
-			panicf("bad SMS escape command: %v", b)
-		}
+	fake := atmodemtest.New(`
+!GSTATUS:
+Current Time:  71465            Temperature: 41
+Reset Counter: 8                Mode:        ONLINE
+System mode:   LTE              PS state:    Attached
+LTE band:      B12              LTE bw:      5 MHz
+LTE Rx chan:   5035             LTE Tx chan: 23035
+LTE CA state:  NOT ASSIGNED
+EMM state:     Registered       Normal Service
+RRC state:     RRC Idle
+IMS reg state: No Srv
 
-		return len(b), nil
-	case 1:
-		if !bytes.Equal(b, []byte("ATZ\r\n")) {
-			panicf("bad AT clear command: %v", b)
-		}
+PCC RxM RSSI:  -84              RSRP (dBm):  -113
+PCC RxD RSSI:  -84              RSRP (dBm):  -111
+Tx Power:      --               TAC:         BEEF (12345)
+RSRQ (dB):     -13.5            Cell ID:     DEADBEEF (1234567)
+SINR (dB):      0.6
 
-		rw.resC <- "OK"
-		return len(b), nil
-	case 2:
-		if !bytes.Equal(b, []byte("ATE0\r\n")) {
-			panicf("bad AT echo off command: %v", b)
-		}
 
-		rw.resC <- "OK"
-		return len(b), nil
-	default:
-		// Otherwise capture the user's input and provide output.
-		rw.resC <- rw.r
-		return rw.w.Write(b)
+OK`)
+
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
 	}
+	defer d.Close()
+
+	d.WithMetrics(noopMetricsSink{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				d.StatusStrict(i%4 == 0)
+				return
+			}
 
+			if _, err := d.Status(context.Background()); err != nil {
+				t.Errorf("failed to fetch status: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
 }
 
-func (rw *readWriteCloser) Close() error { return nil }
+// A noopMetricsSink discards every gauge and counter, used only to exercise
+// the WithMetrics code paths under TestDeviceConcurrentStatus.
+type noopMetricsSink struct{}
+
+func (noopMetricsSink) Gauge(string, float64, map[string]string)  {}
+func (noopMetricsSink) Counter(string, uint64, map[string]string) {}
 
-func panicf(format string, a ...interface{}) {
-	panic(fmt.Sprintf(format, a...))
+func TestDeviceCommandContextCancelled(t *testing.T) {
+	fake := atmodemtest.New("OK")
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := d.Command(ctx, "I"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: got %v, want context.Canceled", err)
+	}
 }
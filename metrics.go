@@ -0,0 +1,124 @@
+package atmodem
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// A MetricsSink receives gauge and counter updates published by a Device
+// configured with WithMetrics. Implementations must be safe for concurrent
+// use.
+type MetricsSink interface {
+	// Gauge reports the current value of name, labeled with labels.
+	Gauge(name string, value float64, labels map[string]string)
+	// Counter increments name by delta, labeled with labels.
+	Counter(name string, delta uint64, labels map[string]string)
+}
+
+// A CommandTracer is invoked after each AT command exchange performed by a
+// Device configured with WithCommandTracer. resp and err are the values
+// which will be returned to the command's caller, and dur is the time taken
+// to complete the exchange.
+type CommandTracer func(cmd string, resp []string, dur time.Duration, err error)
+
+// WithMetrics configures d to publish metrics to sink for every command and
+// Status call, and returns d for chaining.
+func (d *Device) WithMetrics(sink MetricsSink) *Device {
+	d.metrics = sink
+	return d
+}
+
+// WithCommandTracer configures d to invoke tracer after every AT command
+// exchange, and returns d for chaining.
+func (d *Device) WithCommandTracer(tracer CommandTracer) *Device {
+	d.tracer = tracer
+	return d
+}
+
+// command queues cmd behind any other in-flight exchange, honoring ctx
+// cancellation, and invokes the configured CommandTracer and publishes an
+// atmodem_commands_total counter to the configured MetricsSink if either is
+// set.
+func (d *Device) command(ctx context.Context, cmd string) ([]string, error) {
+	if d.tracer == nil && d.metrics == nil {
+		// Avoid the overhead of timing when neither hook is configured.
+		return d.exec(ctx, cmd)
+	}
+
+	start := time.Now()
+	resp, err := d.exec(ctx, cmd)
+	dur := time.Since(start)
+
+	if d.tracer != nil {
+		d.tracer(cmd, resp, dur, err)
+	}
+
+	if d.metrics != nil {
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+
+		d.metrics.Counter("atmodem_commands_total", 1, map[string]string{
+			"command": commandVerb(cmd),
+			"result":  result,
+		})
+	}
+
+	return resp, err
+}
+
+// commandVerb returns the AT command mnemonic prefixing cmd, such as "+CMGS"
+// from "+CMGS=13\r...\x1a" or "+CGDCONT" from "+CGDCONT=1,\"IP\",\"isp\"".
+// Unlike cmd itself, the verb carries no argument data, keeping metric label
+// cardinality bounded regardless of how many distinct arguments a command is
+// issued with.
+func commandVerb(cmd string) string {
+	if i := strings.IndexAny(cmd, "=?\r"); i != -1 {
+		return cmd[:i]
+	}
+
+	return cmd
+}
+
+// publishStatusMetrics publishes gauges derived from s to the configured
+// MetricsSink, labeled by IMEI and model if available from a prior or
+// best-effort Info call. It is a no-op if no MetricsSink is configured.
+func (d *Device) publishStatusMetrics(ctx context.Context, s *Status) {
+	if d.metrics == nil {
+		return
+	}
+
+	d.mu.Lock()
+	info := d.info
+	d.mu.Unlock()
+
+	if info == nil {
+		// Best-effort: label metrics with IMEI/model when available, but
+		// don't fail Status calls if Info is unsupported or errors.
+		if i, err := d.Info(ctx); err == nil {
+			info = i
+
+			d.mu.Lock()
+			d.info = info
+			d.mu.Unlock()
+		}
+	}
+
+	labels := map[string]string{}
+	if info != nil {
+		labels["imei"] = info.IMEI
+		labels["model"] = info.Model
+	}
+
+	d.metrics.Gauge("atmodem_rsrp_dbm", float64(s.RSRPRXMdBm), labels)
+	d.metrics.Gauge("atmodem_rsrq_db", s.RSRQdB, labels)
+	d.metrics.Gauge("atmodem_sinr_db", s.SINRdB, labels)
+	d.metrics.Gauge("atmodem_temperature_celsius", float64(s.Temperature), labels)
+	if s.TransmitPower != nil {
+		d.metrics.Gauge("atmodem_tx_power_dbm", float64(*s.TransmitPower), labels)
+	}
+	d.metrics.Gauge("atmodem_reset_counter", float64(s.ResetCounter), labels)
+	d.metrics.Gauge("atmodem_current_time_seconds", s.CurrentTime.Seconds(), labels)
+}
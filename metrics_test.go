@@ -0,0 +1,139 @@
+package atmodem_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/atmodem"
+	"github.com/mdlayher/atmodem/atmodemtest"
+)
+
+// A testMetricsSink is an atmodem.MetricsSink that records the most recent
+// value of each gauge and the running total of each counter, ignoring
+// labels, safe for concurrent use.
+type testMetricsSink struct {
+	mu       sync.Mutex
+	gauges   map[string]float64
+	counters map[string]uint64
+}
+
+func newTestMetricsSink() *testMetricsSink {
+	return &testMetricsSink{
+		gauges:   make(map[string]float64),
+		counters: make(map[string]uint64),
+	}
+}
+
+func (s *testMetricsSink) Gauge(name string, value float64, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gauges[name] = value
+}
+
+func (s *testMetricsSink) Counter(name string, delta uint64, _ map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[name] += delta
+}
+
+// A traceCall records one CommandTracer invocation.
+type traceCall struct {
+	cmd  string
+	resp []string
+	dur  time.Duration
+	err  error
+}
+
+func TestDeviceMetricsAndTracer(t *testing.T) {
+	const statusRes = `
+!GSTATUS:
+Current Time:  71465            Temperature: 41
+Reset Counter: 8                Mode:        ONLINE
+System mode:   LTE              PS state:    Attached
+LTE band:      B12              LTE bw:      5 MHz
+LTE Rx chan:   5035             LTE Tx chan: 23035
+LTE CA state:  NOT ASSIGNED
+EMM state:     Registered       Normal Service
+RRC state:     RRC Idle
+IMS reg state: No Srv
+
+PCC RxM RSSI:  -84              RSRP (dBm):  -113
+PCC RxD RSSI:  -84              RSRP (dBm):  -111
+Tx Power:      --               TAC:         BEEF (12345)
+RSRQ (dB):     -13.5            Cell ID:     DEADBEEF (1234567)
+SINR (dB):      0.6
+
+
+OK`
+
+	fake := atmodemtest.New(statusRes)
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	sink := newTestMetricsSink()
+
+	var mu sync.Mutex
+	var traces []traceCall
+	d.WithMetrics(sink).WithCommandTracer(func(cmd string, resp []string, dur time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		traces = append(traces, traceCall{cmd: cmd, resp: resp, dur: dur, err: err})
+	})
+
+	// Status itself issues the !GSTATUS? exchange, then publishStatusMetrics
+	// makes its own best-effort Info call to label the resulting gauges, so
+	// a single Status call traces two commands.
+	if _, err := d.Status(context.Background()); err != nil {
+		t.Fatalf("failed to fetch status: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(traces) != 2 {
+		t.Fatalf("unexpected trace count: got %d, want 2", len(traces))
+	}
+
+	first := traces[0]
+	if first.cmd != "!GSTATUS?" {
+		t.Fatalf("unexpected traced command: got %q, want %q", first.cmd, "!GSTATUS?")
+	}
+	if first.err != nil {
+		t.Fatalf("unexpected traced error: %v", first.err)
+	}
+	if first.dur < 0 {
+		t.Fatalf("unexpected negative traced duration: %v", first.dur)
+	}
+	if len(first.resp) == 0 {
+		t.Fatal("expected the traced response to include the status response lines")
+	}
+
+	if second := traces[1]; second.cmd != "I" {
+		t.Fatalf("unexpected second traced command: got %q, want %q", second.cmd, "I")
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	wantGauges := map[string]float64{
+		"atmodem_rsrp_dbm":             -113,
+		"atmodem_rsrq_db":              -13.5,
+		"atmodem_sinr_db":              0.6,
+		"atmodem_temperature_celsius":  41,
+		"atmodem_reset_counter":        8,
+		"atmodem_current_time_seconds": 71465,
+	}
+	if diff := cmp.Diff(wantGauges, sink.gauges); diff != "" {
+		t.Fatalf("unexpected gauges (-want +got):\n%s", diff)
+	}
+
+	if want, got := uint64(2), sink.counters["atmodem_commands_total"]; want != got {
+		t.Fatalf("unexpected command counter: got %d, want %d", got, want)
+	}
+}
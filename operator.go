@@ -0,0 +1,55 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// An Operator describes the modem's currently selected network operator, as
+// reported by AT+COPS?.
+type Operator struct {
+	Mode   int
+	Format int
+	Name   string
+	AcT    int
+}
+
+// Operator requests the modem's currently selected network operator using
+// AT+COPS?.
+func (d *Device) Operator(ctx context.Context) (*Operator, error) {
+	ss, err := d.command(ctx, "+COPS?")
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, errors.New("atmodem: empty operator response from modem")
+	}
+
+	return parseOperator(ss[0])
+}
+
+// parseOperator unpacks an Operator from a +COPS response line.
+func parseOperator(line string) (*Operator, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("atmodem: unexpected operator response %q", line)
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	op := &Operator{Mode: vp.IntAt(0)}
+	if len(fields) > 3 {
+		op.Format = vp.IntAt(1)
+		op.Name = vp.QuotedStringAt(2)
+		op.AcT = vp.IntAt(3)
+	}
+
+	return op, vp.Err()
+}
@@ -0,0 +1,58 @@
+package atmodem
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseOperator(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		op   *Operator
+		ok   bool
+	}{
+		{
+			name: "malformed",
+			line: "+COPS 0",
+		},
+		{
+			name: "mode only, not registered",
+			line: "+COPS: 0",
+			op:   &Operator{Mode: 0},
+			ok:   true,
+		},
+		{
+			name: "registered on LTE",
+			line: `+COPS: 0,0,"Example Wireless",7`,
+			op: &Operator{
+				Mode:   0,
+				Format: 0,
+				Name:   "Example Wireless",
+				AcT:    7,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := parseOperator(tt.line)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse operator: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.op, op); diff != "" {
+				t.Fatalf("unexpected operator (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
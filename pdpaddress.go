@@ -0,0 +1,45 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// PDPAddress requests the IP address assigned to the PDP context identified
+// by cid using AT+CGPADDR.
+func (d *Device) PDPAddress(ctx context.Context, cid int) (netip.Addr, error) {
+	ss, err := d.command(ctx, fmt.Sprintf("+CGPADDR=%d", cid))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if len(ss) == 0 {
+		return netip.Addr{}, errors.New("atmodem: empty PDP address response from modem")
+	}
+
+	return parsePDPAddress(ss[0])
+}
+
+// parsePDPAddress unpacks a netip.Addr from a +CGPADDR response line.
+func parsePDPAddress(line string) (netip.Addr, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if len(fields) < 2 {
+		return netip.Addr{}, fmt.Errorf("atmodem: unexpected PDP address response %q", line)
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	addr, perr := netip.ParseAddr(vp.QuotedStringAt(1))
+	if perr != nil {
+		return netip.Addr{}, fmt.Errorf("atmodem: malformed PDP address %q: %w", line, perr)
+	}
+
+	return addr, vp.Err()
+}
@@ -0,0 +1,50 @@
+package atmodem
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func Test_parsePDPAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		addr netip.Addr
+		ok   bool
+	}{
+		{
+			name: "malformed",
+			line: "+CGPADDR 1",
+		},
+		{
+			name: "bad address",
+			line: `+CGPADDR: 1,"not an IP"`,
+		},
+		{
+			name: "OK IPv4",
+			line: `+CGPADDR: 1,"10.0.0.1"`,
+			addr: netip.MustParseAddr("10.0.0.1"),
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := parsePDPAddress(tt.line)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse PDP address: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if addr != tt.addr {
+				t.Fatalf("unexpected address: got %s, want %s", addr, tt.addr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package atmodem
+
+import (
+	"context"
+	"fmt"
+)
+
+// A PDPContext describes one packet data protocol context definition, as
+// reported by AT+CGDCONT?.
+type PDPContext struct {
+	CID     int
+	Type    string
+	APN     string
+	Address string
+}
+
+// PDPContexts requests the modem's currently defined PDP contexts using
+// AT+CGDCONT?.
+func (d *Device) PDPContexts(ctx context.Context) ([]PDPContext, error) {
+	ss, err := d.command(ctx, "+CGDCONT?")
+	if err != nil {
+		return nil, err
+	}
+
+	ctxs := make([]PDPContext, 0, len(ss))
+	for _, l := range ss {
+		ctx, err := parsePDPContext(l)
+		if err != nil {
+			return nil, err
+		}
+
+		ctxs = append(ctxs, *ctx)
+	}
+
+	return ctxs, nil
+}
+
+// parsePDPContext unpacks a PDPContext from a +CGDCONT response line.
+func parsePDPContext(line string) (*PDPContext, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("atmodem: unexpected PDP context response %q", line)
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := &PDPContext{
+		CID:  vp.IntAt(0),
+		Type: vp.QuotedStringAt(1),
+		APN:  vp.QuotedStringAt(2),
+	}
+	if len(fields) > 3 {
+		ctx.Address = vp.QuotedStringAt(3)
+	}
+
+	return ctx, vp.Err()
+}
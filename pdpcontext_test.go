@@ -0,0 +1,56 @@
+package atmodem
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parsePDPContext(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		ctx  *PDPContext
+		ok   bool
+	}{
+		{
+			name: "malformed",
+			line: "+CGDCONT 1,IP",
+		},
+		{
+			name: "not enough fields",
+			line: "+CGDCONT: 1,IP",
+		},
+		{
+			name: "OK with address",
+			line: `+CGDCONT: 1,"IP","internet","10.0.0.1",0,0`,
+			ctx: &PDPContext{
+				CID:     1,
+				Type:    "IP",
+				APN:     "internet",
+				Address: "10.0.0.1",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := parsePDPContext(tt.line)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse PDP context: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.ctx, ctx); diff != "" {
+				t.Fatalf("unexpected PDP context (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package promexp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// encodeLabels serializes labels into a deterministic string suitable for
+// use as part of a metricKey, so equivalent label sets hash identically
+// regardless of map iteration order.
+func encodeLabels(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, n := range names {
+		b.WriteString(n)
+		b.WriteByte('=')
+		b.WriteString(labels[n])
+		b.WriteByte(';')
+	}
+
+	return b.String()
+}
+
+// describe reconstructs a prometheus.Desc and its ordered label values from
+// k, so Collect can emit a constant metric without having retained the
+// original label map.
+func (k metricKey) describe() (*prometheus.Desc, []string) {
+	var names, values []string
+	for _, pair := range strings.Split(strings.TrimSuffix(k.labels, ";"), ";") {
+		if pair == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(pair, "=")
+		names = append(names, name)
+		values = append(values, value)
+	}
+
+	return prometheus.NewDesc(k.name, "", names, nil), values
+}
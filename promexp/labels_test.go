@@ -0,0 +1,24 @@
+package promexp
+
+import "testing"
+
+func Test_encodeLabelsDeterministic(t *testing.T) {
+	a := encodeLabels(map[string]string{"imei": "123", "model": "MC7455"})
+	b := encodeLabels(map[string]string{"model": "MC7455", "imei": "123"})
+
+	if a != b {
+		t.Fatalf("unexpected label encoding: got %q and %q, want identical", a, b)
+	}
+}
+
+func Test_metricKeyDescribeRoundTrip(t *testing.T) {
+	k := newMetricKey("atmodem_rsrp_dbm", map[string]string{"imei": "123", "model": "MC7455"})
+
+	desc, values := k.describe()
+	if desc == nil {
+		t.Fatal("expected a non-nil desc")
+	}
+	if len(values) != 2 {
+		t.Fatalf("unexpected label value count: got %d, want 2", len(values))
+	}
+}
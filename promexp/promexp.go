@@ -0,0 +1,84 @@
+// Package promexp adapts atmodem.MetricsSink to a Prometheus
+// prometheus.Collector, so a Device's gauges and counters can be registered
+// with a Prometheus registry and scraped directly.
+package promexp
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// A Collector is a prometheus.Collector which also implements
+// atmodem.MetricsSink, suitable for passing to Device.WithMetrics and then
+// registering with a prometheus.Registerer.
+type Collector struct {
+	mu       sync.Mutex
+	gauges   map[metricKey]float64
+	counters map[metricKey]uint64
+}
+
+// metricKey uniquely identifies a metric by name and its label values,
+// joined in label-name order so two calls with equivalent labels collapse
+// to the same series.
+type metricKey struct {
+	name   string
+	labels string
+}
+
+// New creates a Collector ready for use with Device.WithMetrics and
+// prometheus.Registerer.MustRegister.
+func New() *Collector {
+	return &Collector{
+		gauges:   make(map[metricKey]float64),
+		counters: make(map[metricKey]uint64),
+	}
+}
+
+var _ interface {
+	Gauge(name string, value float64, labels map[string]string)
+	Counter(name string, delta uint64, labels map[string]string)
+} = (*Collector)(nil)
+
+// Gauge implements atmodem.MetricsSink.
+func (c *Collector) Gauge(name string, value float64, labels map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gauges[newMetricKey(name, labels)] = value
+}
+
+// Counter implements atmodem.MetricsSink.
+func (c *Collector) Counter(name string, delta uint64, labels map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := newMetricKey(name, labels)
+	c.counters[k] += delta
+}
+
+// Describe implements prometheus.Collector. No descriptors are sent,
+// marking this as an unchecked collector since metric names and labels are
+// only known once Device begins publishing values.
+func (c *Collector) Describe(_ chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range c.gauges {
+		desc, values := k.describe()
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, values...)
+	}
+
+	for k, v := range c.counters {
+		desc, values := k.describe()
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v), values...)
+	}
+}
+
+// newMetricKey builds a metricKey from a metric name and its labels.
+func newMetricKey(name string, labels map[string]string) metricKey {
+	return metricKey{name: name, labels: encodeLabels(labels)}
+}
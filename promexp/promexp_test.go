@@ -0,0 +1,29 @@
+package promexp
+
+import "testing"
+
+func TestCollectorGaugeOverwrites(t *testing.T) {
+	c := New()
+
+	labels := map[string]string{"imei": "123"}
+	c.Gauge("atmodem_rsrp_dbm", -90, labels)
+	c.Gauge("atmodem_rsrp_dbm", -85, labels)
+
+	got := c.gauges[newMetricKey("atmodem_rsrp_dbm", labels)]
+	if got != -85 {
+		t.Fatalf("unexpected gauge value: got %v, want -85", got)
+	}
+}
+
+func TestCollectorCounterAccumulates(t *testing.T) {
+	c := New()
+
+	labels := map[string]string{"command": "+CSQ", "result": "ok"}
+	c.Counter("atmodem_commands_total", 1, labels)
+	c.Counter("atmodem_commands_total", 2, labels)
+
+	got := c.counters[newMetricKey("atmodem_commands_total", labels)]
+	if got != 3 {
+		t.Fatalf("unexpected counter value: got %d, want 3", got)
+	}
+}
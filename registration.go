@@ -0,0 +1,116 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// A RegistrationDomain identifies which of the circuit-switched,
+// packet-switched, or EPS registration status a Registration describes.
+type RegistrationDomain int
+
+// Possible RegistrationDomain values.
+const (
+	DomainCircuit RegistrationDomain = iota
+	DomainPacket
+	DomainEPS
+)
+
+// String returns the human-readable name of d.
+func (d RegistrationDomain) String() string {
+	switch d {
+	case DomainCircuit:
+		return "circuit"
+	case DomainPacket:
+		return "packet"
+	case DomainEPS:
+		return "EPS"
+	default:
+		return fmt.Sprintf("RegistrationDomain(%d)", int(d))
+	}
+}
+
+// A RegistrationStat enumerates the network registration states reported by
+// AT+CREG?, AT+CGREG?, and AT+CEREG?.
+type RegistrationStat int
+
+// Possible RegistrationStat values.
+const (
+	StatNotRegistered RegistrationStat = iota
+	StatRegisteredHome
+	StatSearching
+	StatDenied
+	StatUnknown
+	StatRegisteredRoaming
+)
+
+// A Registration describes the modem's current network registration status
+// for one domain.
+type Registration struct {
+	Domain RegistrationDomain
+	// N is the unsolicited result code reporting mode the modem last
+	// accepted via AT+CREG=n/AT+CGREG=n/AT+CEREG=n, echoed back as the first
+	// field of the corresponding read command's response.
+	N       int
+	Stat    RegistrationStat
+	LAC, CI string
+	AcT     int
+}
+
+// NetworkRegistration requests the modem's current network registration
+// status, preferring EPS (LTE) status and falling back to packet-switched
+// and then circuit-switched status for older radio access technologies.
+func (d *Device) NetworkRegistration(ctx context.Context) (*Registration, error) {
+	queries := []struct {
+		cmd    string
+		domain RegistrationDomain
+	}{
+		{"+CEREG?", DomainEPS},
+		{"+CGREG?", DomainPacket},
+		{"+CREG?", DomainCircuit},
+	}
+
+	for _, q := range queries {
+		ss, err := d.command(ctx, q.cmd)
+		if err != nil || len(ss) == 0 {
+			continue
+		}
+
+		return parseRegistration(q.domain, ss[0])
+	}
+
+	return nil, errors.New("atmodem: no network registration status reported by modem")
+}
+
+// parseRegistration unpacks a Registration from a +CREG/+CGREG/+CEREG
+// response line.
+func parseRegistration(domain RegistrationDomain, line string) (*Registration, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("atmodem: unexpected network registration response %q", line)
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &Registration{
+		Domain: domain,
+		N:      vp.IntAt(0),
+		Stat:   RegistrationStat(vp.IntAt(1)),
+	}
+	if len(fields) > 3 {
+		reg.LAC = vp.QuotedStringAt(2)
+		reg.CI = vp.QuotedStringAt(3)
+	}
+	if len(fields) > 4 {
+		reg.AcT = vp.IntAt(4)
+	}
+
+	return reg, vp.Err()
+}
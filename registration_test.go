@@ -0,0 +1,69 @@
+package atmodem
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseRegistration(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		reg  *Registration
+		ok   bool
+	}{
+		{
+			name: "malformed",
+			line: "+CREG 1",
+		},
+		{
+			name: "not enough fields",
+			line: "+CREG: 1",
+		},
+		{
+			name: "home, no location",
+			line: "+CREG: 0,1",
+			reg:  &Registration{Domain: DomainCircuit, N: 0, Stat: StatRegisteredHome},
+			ok:   true,
+		},
+		{
+			name: "roaming with location and AcT",
+			line: `+CEREG: 2,5,"1A2B","0C3D44E",7`,
+			reg: &Registration{
+				Domain: DomainEPS,
+				N:      2,
+				Stat:   StatRegisteredRoaming,
+				LAC:    "1A2B",
+				CI:     "0C3D44E",
+				AcT:    7,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := DomainCircuit
+			if tt.reg != nil {
+				domain = tt.reg.Domain
+			}
+
+			reg, err := parseRegistration(domain, tt.line)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse registration: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.reg, reg); diff != "" {
+				t.Fatalf("unexpected registration (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
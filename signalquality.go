@@ -0,0 +1,73 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+)
+
+// A SignalQuality describes the modem's current received signal quality, as
+// reported by AT+CSQ.
+type SignalQuality struct {
+	// RSSIdBm is the received signal strength, or nil if not known to the
+	// modem.
+	RSSIdBm *int
+	// BERPercent is the bit error rate as a percentage, or nil if not known
+	// to the modem or not applicable to the current radio access technology.
+	BERPercent *float64
+}
+
+// berPercent maps the raw 3GPP TS 27.007 BER scale (0-7) to an approximate
+// bit error rate percentage, per 3GPP TS 45.008.
+var berPercent = map[int]float64{
+	0: 0.14,
+	1: 0.28,
+	2: 0.57,
+	3: 1.13,
+	4: 2.26,
+	5: 4.53,
+	6: 9.05,
+	7: 18.10,
+}
+
+// SignalQuality requests the modem's current received signal quality using
+// AT+CSQ.
+func (d *Device) SignalQuality(ctx context.Context) (*SignalQuality, error) {
+	ss, err := d.command(ctx, "+CSQ")
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, errors.New("atmodem: empty signal quality response from modem")
+	}
+
+	return parseSignalQuality(ss[0])
+}
+
+// parseSignalQuality unpacks a SignalQuality structure from a +CSQ response
+// line.
+func parseSignalQuality(line string) (*SignalQuality, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return nil, err
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var sq SignalQuality
+	if rssi := vp.IntAt(0); rssi != 99 {
+		dBm := -113 + 2*rssi
+		sq.RSSIdBm = &dBm
+	}
+	if len(fields) > 1 {
+		if raw := vp.IntAt(1); raw != 99 {
+			if pct, ok := berPercent[raw]; ok {
+				sq.BERPercent = &pct
+			}
+		}
+	}
+
+	return &sq, vp.Err()
+}
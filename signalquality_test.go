@@ -0,0 +1,59 @@
+package atmodem
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseSignalQuality(t *testing.T) {
+	intp := func(v int) *int { return &v }
+	floatp := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name string
+		line string
+		sq   *SignalQuality
+		ok   bool
+	}{
+		{
+			name: "malformed",
+			line: "+CSQ 15,99",
+		},
+		{
+			name: "unknown",
+			line: "+CSQ: 99,99",
+			sq:   &SignalQuality{},
+			ok:   true,
+		},
+		{
+			name: "known RSSI and BER",
+			line: "+CSQ: 15,3",
+			sq: &SignalQuality{
+				RSSIdBm:    intp(-83),
+				BERPercent: floatp(1.13),
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sq, err := parseSignalQuality(tt.line)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse signal quality: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.sq, sq); diff != "" {
+				t.Fatalf("unexpected signal quality (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mdlayher/atmodem/sms"
+)
+
+// SendSMS sends msg to dest over SMS PDU mode, automatically splitting msg
+// into multiple concatenated parts if it does not fit within a single SMS
+// TPDU. It returns the message reference the modem assigned to the final
+// part.
+//
+// AT+CMGS expects the modem to reply with a "> " prompt before the PDU and
+// its Ctrl-Z terminator are sent; the underlying AT transport accepts this
+// entire exchange as a single command string.
+func (d *Device) SendSMS(ctx context.Context, dest, msg string, opts ...sms.SendOption) (ref int, err error) {
+	if err := d.setSMSPDUMode(ctx); err != nil {
+		return 0, err
+	}
+
+	pdus, err := sms.EncodeSubmit(dest, msg, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range pdus {
+		ss, err := d.command(ctx, fmt.Sprintf("+CMGS=%d\r%s\x1a", p.Octets, p.TPDU))
+		if err != nil {
+			return 0, err
+		}
+		if len(ss) == 0 {
+			return 0, errors.New("atmodem: empty send SMS response from modem")
+		}
+
+		if ref, err = parseSMSRef(ss[0]); err != nil {
+			return 0, err
+		}
+	}
+
+	return ref, nil
+}
+
+// parseSMSRef unpacks the message reference from a +CMGS response line.
+func parseSMSRef(line string) (int, error) {
+	fields, err := splitFields(line)
+	if err != nil {
+		return 0, err
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return 0, err
+	}
+
+	ref := vp.IntAt(0)
+	return ref, vp.Err()
+}
+
+// ReadSMS reads the message stored at index using AT+CMGR.
+func (d *Device) ReadSMS(ctx context.Context, index int) (*sms.Message, error) {
+	if err := d.setSMSPDUMode(ctx); err != nil {
+		return nil, err
+	}
+
+	ss, err := d.command(ctx, fmt.Sprintf("+CMGR=%d", index))
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) < 2 {
+		return nil, fmt.Errorf("atmodem: unexpected read SMS response: %v", ss)
+	}
+
+	msg, err := sms.DecodeDeliver(ss[1])
+	if err != nil {
+		return nil, err
+	}
+	msg.Index = index
+
+	return msg, nil
+}
+
+// ListSMS lists the messages in modem storage matching filter using
+// AT+CMGL.
+func (d *Device) ListSMS(ctx context.Context, filter sms.Filter) ([]sms.Message, error) {
+	if err := d.setSMSPDUMode(ctx); err != nil {
+		return nil, err
+	}
+
+	ss, err := d.command(ctx, fmt.Sprintf("+CMGL=%d", int(filter)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Each message occupies a "+CMGL: ..." header line followed by a hex PDU
+	// line.
+	var msgs []sms.Message
+	for i := 0; i+1 < len(ss); i += 2 {
+		fields, err := splitFields(ss[i])
+		if err != nil {
+			return nil, err
+		}
+
+		vp, err := newValueParser(fields)
+		if err != nil {
+			return nil, err
+		}
+		index := vp.IntAt(0)
+		if err := vp.Err(); err != nil {
+			return nil, err
+		}
+
+		msg, err := sms.DecodeDeliver(ss[i+1])
+		if err != nil {
+			return nil, err
+		}
+		msg.Index = index
+
+		msgs = append(msgs, *msg)
+	}
+
+	return msgs, nil
+}
+
+// DeleteSMS deletes the message stored at index using AT+CMGD.
+func (d *Device) DeleteSMS(ctx context.Context, index int) error {
+	_, err := d.command(ctx, fmt.Sprintf("+CMGD=%d", index))
+	return err
+}
+
+// SelectSMSStorage selects mem as the message storage used for reading,
+// listing, and deleting SMS (AT+CMGR, AT+CMGL, AT+CMGD) via AT+CPMS. The same
+// storage is selected for all three of AT+CPMS's memory slots, as atmodem
+// does not distinguish between them.
+func (d *Device) SelectSMSStorage(ctx context.Context, mem string) error {
+	if _, err := d.command(ctx, fmt.Sprintf("+CPMS=%q,%q,%q", mem, mem, mem)); err != nil {
+		return fmt.Errorf("atmodem: failed to select SMS storage %q: %w", mem, err)
+	}
+
+	return nil
+}
+
+// setSMSPDUMode selects PDU mode using AT+CMGF, required by SendSMS,
+// ReadSMS, and ListSMS before they can exchange hex-encoded TPDUs.
+func (d *Device) setSMSPDUMode(ctx context.Context) error {
+	if _, err := d.command(ctx, "+CMGF=0"); err != nil {
+		return fmt.Errorf("atmodem: failed to select SMS PDU mode: %w", err)
+	}
+
+	return nil
+}
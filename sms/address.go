@@ -0,0 +1,110 @@
+package sms
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Numbering plan and type-of-number constants used when encoding an Address,
+// per 3GPP TS 23.040 section 9.1.2.5.
+const (
+	tonInternational = 0x91 // International number, ISDN/telephone numbering plan.
+	tonUnknown       = 0x81 // Unknown number type, ISDN/telephone numbering plan.
+)
+
+// An Address is a phone number as encoded in an SMS TPDU, such as an TP-DA
+// (destination) or TP-OA (originating) address.
+type Address struct {
+	// Number is the phone number in international (leading "+") or local
+	// form.
+	Number string
+}
+
+// encode renders a into its TPDU representation: a length octet (the number
+// of decimal digits, not encoded octets), a type-of-address octet, and the
+// semi-octet swapped digits.
+func (a Address) encode() []byte {
+	digits := a.Number
+	ton := byte(tonUnknown)
+	if strings.HasPrefix(digits, "+") {
+		ton = tonInternational
+		digits = digits[1:]
+	}
+
+	b := make([]byte, 0, 2+len(digits)/2+1)
+	b = append(b, byte(len(digits)), ton)
+	return append(b, swapDigits(digits)...)
+}
+
+// decodeAddress parses an Address beginning at b, returning the Address and
+// the number of bytes consumed.
+func decodeAddress(b []byte) (Address, int, error) {
+	if len(b) < 2 {
+		return Address{}, 0, fmt.Errorf("sms: short address: %d bytes", len(b))
+	}
+
+	digitN := int(b[0])
+	ton := b[1]
+
+	octets := (digitN + 1) / 2
+	if len(b) < 2+octets {
+		return Address{}, 0, fmt.Errorf("sms: truncated address: need %d digit octets, have %d", octets, len(b)-2)
+	}
+
+	digits := unswapDigits(b[2:2+octets], digitN)
+	if ton == tonInternational {
+		digits = "+" + digits
+	}
+
+	return Address{Number: digits}, 2 + octets, nil
+}
+
+// swapDigits encodes a decimal digit string into BCD semi-octets, swapping
+// the nibbles of each octet and padding an odd final digit with 0xF.
+func swapDigits(digits string) []byte {
+	if len(digits)%2 != 0 {
+		digits += "F"
+	}
+
+	b := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		lo := digits[i] - '0'
+		hi := digits[i+1] - '0'
+		if digits[i+1] == 'F' {
+			hi = 0x0F
+		}
+
+		b = append(b, (hi<<4)|(lo&0x0F))
+	}
+
+	return b
+}
+
+// unswapDigits decodes n BCD semi-octet swapped digits from b.
+func unswapDigits(b []byte, n int) string {
+	var sb strings.Builder
+	sb.Grow(n)
+
+	for _, o := range b {
+		lo := o & 0x0F
+		hi := o >> 4
+
+		if sb.Len() < n {
+			sb.WriteByte(nibbleDigit(lo))
+		}
+		if sb.Len() < n {
+			sb.WriteByte(nibbleDigit(hi))
+		}
+	}
+
+	return sb.String()
+}
+
+// nibbleDigit renders a single BCD nibble as its ASCII digit.
+func nibbleDigit(nibble byte) byte {
+	if nibble > 9 {
+		return '?'
+	}
+
+	return '0' + nibble
+}
@@ -0,0 +1,52 @@
+package sms
+
+import "testing"
+
+func Test_addressRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+	}{
+		{name: "international, even digits", number: "+15551234567"},
+		{name: "international, odd digits", number: "+441234567"},
+		{name: "local, even digits", number: "5551234567"},
+		{name: "local, odd digits", number: "555123456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := Address{Number: tt.number}.encode()
+
+			got, n, err := decodeAddress(enc)
+			if err != nil {
+				t.Fatalf("failed to decode address: %v", err)
+			}
+			if n != len(enc) {
+				t.Fatalf("unexpected consumed bytes: got %d, want %d", n, len(enc))
+			}
+
+			if got.Number != tt.number {
+				t.Fatalf("unexpected number: got %q, want %q", got.Number, tt.number)
+			}
+		})
+	}
+}
+
+func Test_decodeAddressErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "empty", b: nil},
+		{name: "short length byte only", b: []byte{0x01}},
+		{name: "truncated digits", b: []byte{0x04, 0x91, 0x21}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := decodeAddress(tt.b); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
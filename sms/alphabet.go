@@ -0,0 +1,148 @@
+package sms
+
+import "fmt"
+
+// escape is the GSM 03.38 escape-to-extension-table septet.
+const escape = 0x1B
+
+// basicTable maps each GSM 03.38 default alphabet septet to its Unicode rune.
+var basicTable = [128]rune{
+	0x00: '@', 0x01: '£', 0x02: '$', 0x03: '¥', 0x04: 'è', 0x05: 'é', 0x06: 'ù',
+	0x07: 'ì', 0x08: 'ò', 0x09: 'Ç', 0x0A: '\n', 0x0B: 'Ø', 0x0C: 'ø', 0x0D: '\r',
+	0x0E: 'Å', 0x0F: 'å', 0x10: 'Δ', 0x11: '_', 0x12: 'Φ', 0x13: 'Γ', 0x14: 'Λ',
+	0x15: 'Ω', 0x16: 'Π', 0x17: 'Ψ', 0x18: 'Σ', 0x19: 'Θ', 0x1A: 'Ξ', 0x1B: escapeRune,
+	0x1C: 'Æ', 0x1D: 'æ', 0x1E: 'ß', 0x1F: 'É', 0x20: ' ', 0x21: '!', 0x22: '"',
+	0x23: '#', 0x24: '¤', 0x25: '%', 0x26: '&', 0x27: '\'', 0x28: '(', 0x29: ')',
+	0x2A: '*', 0x2B: '+', 0x2C: ',', 0x2D: '-', 0x2E: '.', 0x2F: '/', 0x30: '0',
+	0x31: '1', 0x32: '2', 0x33: '3', 0x34: '4', 0x35: '5', 0x36: '6', 0x37: '7',
+	0x38: '8', 0x39: '9', 0x3A: ':', 0x3B: ';', 0x3C: '<', 0x3D: '=', 0x3E: '>',
+	0x3F: '?', 0x40: '¡', 0x41: 'A', 0x42: 'B', 0x43: 'C', 0x44: 'D', 0x45: 'E',
+	0x46: 'F', 0x47: 'G', 0x48: 'H', 0x49: 'I', 0x4A: 'J', 0x4B: 'K', 0x4C: 'L',
+	0x4D: 'M', 0x4E: 'N', 0x4F: 'O', 0x50: 'P', 0x51: 'Q', 0x52: 'R', 0x53: 'S',
+	0x54: 'T', 0x55: 'U', 0x56: 'V', 0x57: 'W', 0x58: 'X', 0x59: 'Y', 0x5A: 'Z',
+	0x5B: 'Ä', 0x5C: 'Ö', 0x5D: 'Ñ', 0x5E: 'Ü', 0x5F: '§', 0x60: '¿', 0x61: 'a',
+	0x62: 'b', 0x63: 'c', 0x64: 'd', 0x65: 'e', 0x66: 'f', 0x67: 'g', 0x68: 'h',
+	0x69: 'i', 0x6A: 'j', 0x6B: 'k', 0x6C: 'l', 0x6D: 'm', 0x6E: 'n', 0x6F: 'o',
+	0x70: 'p', 0x71: 'q', 0x72: 'r', 0x73: 's', 0x74: 't', 0x75: 'u', 0x76: 'v',
+	0x77: 'w', 0x78: 'x', 0x79: 'y', 0x7A: 'z', 0x7B: 'ä', 0x7C: 'ö', 0x7D: 'ñ',
+	0x7E: 'ü', 0x7F: 'à',
+}
+
+// escapeRune is a sentinel stored in basicTable at the escape septet; it is
+// never itself a valid decoded rune.
+const escapeRune rune = -1
+
+// extTable maps each GSM 03.38 extension table septet (following an escape
+// septet) to its Unicode rune.
+var extTable = map[byte]rune{
+	0x0A: '\f', 0x14: '^', 0x28: '{', 0x29: '}', 0x2F: '\\', 0x3C: '[',
+	0x3D: '~', 0x3E: ']', 0x40: '|', 0x65: '€',
+}
+
+// runeToSeptet and runeToExt are the inverses of basicTable and extTable,
+// built once at package initialization.
+var (
+	runeToSeptet = make(map[rune]byte, len(basicTable))
+	runeToExt    = make(map[rune]byte, len(extTable))
+)
+
+func init() {
+	for b, r := range basicTable {
+		if r == escapeRune {
+			continue
+		}
+		runeToSeptet[r] = byte(b)
+	}
+	for b, r := range extTable {
+		runeToExt[r] = b
+	}
+}
+
+// encodeSeptets converts s into a sequence of GSM 03.38 septets (one or two
+// per rune, in the case of extension table characters). It returns an error
+// if s contains a rune which cannot be represented in the default alphabet.
+func encodeSeptets(s string) ([]byte, error) {
+	septets := make([]byte, 0, len(s))
+	for _, r := range s {
+		if b, ok := runeToSeptet[r]; ok {
+			septets = append(septets, b)
+			continue
+		}
+		if b, ok := runeToExt[r]; ok {
+			septets = append(septets, escape, b)
+			continue
+		}
+
+		return nil, fmt.Errorf("sms: rune %q cannot be encoded in the GSM 03.38 default alphabet", r)
+	}
+
+	return septets, nil
+}
+
+// decodeSeptets converts a sequence of GSM 03.38 septets back into a string.
+func decodeSeptets(septets []byte) string {
+	rs := make([]rune, 0, len(septets))
+	for i := 0; i < len(septets); i++ {
+		b := septets[i]
+		if b == escape && i+1 < len(septets) {
+			i++
+			if r, ok := extTable[septets[i]]; ok {
+				rs = append(rs, r)
+				continue
+			}
+
+			// Unrecognized extension septet: per 3GPP TS 23.038, display as a
+			// space.
+			rs = append(rs, ' ')
+			continue
+		}
+
+		rs = append(rs, basicTable[b])
+	}
+
+	return string(rs)
+}
+
+// packSeptets packs a slice of 7-bit septets into 8-bit octets, as required
+// by the SMS TPDU user data field. Septets are treated as an LSB-first bit
+// stream, matching the packing described in 3GPP TS 23.038.
+func packSeptets(septets []byte) []byte {
+	return packSeptetsOffset(septets, 0)
+}
+
+// packSeptetsOffset is like packSeptets, but begins writing septets after
+// offsetBits zero bits, used to pad the user data header to a septet
+// boundary when a UDH is present.
+func packSeptetsOffset(septets []byte, offsetBits uint) []byte {
+	var w bitWriter
+	w.write(0, offsetBits)
+	for _, s := range septets {
+		w.write(uint32(s&0x7F), 7)
+	}
+
+	return w.bytes()
+}
+
+// unpackSeptets unpacks n 7-bit septets from packed 8-bit octets.
+func unpackSeptets(octets []byte, n int) []byte {
+	return unpackSeptetsOffset(octets, n, 0)
+}
+
+// unpackSeptetsOffset is like unpackSeptets, but skips offsetBits bits
+// before reading septets, mirroring packSeptetsOffset.
+func unpackSeptetsOffset(octets []byte, n int, offsetBits uint) []byte {
+	r := bitReader{data: octets}
+	r.skip(offsetBits)
+
+	septets := make([]byte, 0, n)
+	for i := 0; i < n; i++ {
+		v, ok := r.read(7)
+		if !ok {
+			break
+		}
+
+		septets = append(septets, byte(v))
+	}
+
+	return septets
+}
@@ -0,0 +1,80 @@
+package sms
+
+import "testing"
+
+func Test_encodeDecodeSeptets(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		ok   bool
+	}{
+		{
+			name: "basic alphabet",
+			s:    "Hello, World! 123",
+			ok:   true,
+		},
+		{
+			name: "extension table",
+			s:    "a{b}c[d]e~f|g\\h^i€j",
+			ok:   true,
+		},
+		{
+			name: "unencodable rune",
+			s:    "hi 世界",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			septets, err := encodeSeptets(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to encode septets: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if got := decodeSeptets(septets); got != tt.s {
+				t.Fatalf("unexpected round-trip: got %q, want %q", got, tt.s)
+			}
+		})
+	}
+}
+
+func Test_packUnpackSeptets(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "empty", s: ""},
+		{name: "one char", s: "A"},
+		{name: "seven chars", s: "ABCDEFG"},
+		{name: "160 chars", s: func() string {
+			s := make([]byte, 160)
+			for i := range s {
+				s[i] = 'A'
+			}
+			return string(s)
+		}()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			septets, err := encodeSeptets(tt.s)
+			if err != nil {
+				t.Fatalf("failed to encode septets: %v", err)
+			}
+
+			packed := packSeptets(septets)
+			unpacked := unpackSeptets(packed, len(septets))
+
+			if got := decodeSeptets(unpacked); got != tt.s {
+				t.Fatalf("unexpected round-trip: got %q, want %q", got, tt.s)
+			}
+		})
+	}
+}
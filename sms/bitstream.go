@@ -0,0 +1,56 @@
+package sms
+
+// A bitWriter accumulates values of arbitrary bit width into a byte slice,
+// least-significant-bit first, as required when packing GSM 7-bit septets
+// and raw header octets into the same TPDU user data bit stream.
+type bitWriter struct {
+	out    []byte
+	bitPos int
+}
+
+// write appends the low n bits of v to w.
+func (w *bitWriter) write(v uint32, n uint) {
+	for i := uint(0); i < n; i++ {
+		byteIdx := w.bitPos / 8
+		bitIdx := uint(w.bitPos) % 8
+		for len(w.out) <= byteIdx {
+			w.out = append(w.out, 0)
+		}
+
+		if (v>>i)&1 != 0 {
+			w.out[byteIdx] |= 1 << bitIdx
+		}
+		w.bitPos++
+	}
+}
+
+// bytes returns the packed output accumulated so far.
+func (w *bitWriter) bytes() []byte { return w.out }
+
+// A bitReader consumes values of arbitrary bit width from a byte slice,
+// least-significant-bit first, mirroring bitWriter.
+type bitReader struct {
+	data   []byte
+	bitPos int
+}
+
+// read reads the next n bits (n <= 32). ok is false if fewer than n bits
+// remain.
+func (r *bitReader) read(n uint) (v uint32, ok bool) {
+	for i := uint(0); i < n; i++ {
+		byteIdx := r.bitPos / 8
+		if byteIdx >= len(r.data) {
+			return 0, false
+		}
+
+		bitIdx := uint(r.bitPos) % 8
+		bit := (r.data[byteIdx] >> bitIdx) & 1
+		v |= uint32(bit) << i
+		r.bitPos++
+	}
+
+	return v, true
+}
+
+// skip advances the read position by n bits.
+func (r *bitReader) skip(n uint) { r.bitPos += int(n) }
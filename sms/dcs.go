@@ -0,0 +1,47 @@
+package sms
+
+// An Alphabet identifies the character encoding used for an SMS TPDU's user
+// data, as carried in the TP-DCS octet.
+type Alphabet int
+
+// Possible Alphabet values.
+const (
+	// Alphabet7Bit is the GSM 03.38 default 7-bit alphabet.
+	Alphabet7Bit Alphabet = iota
+	// Alphabet8Bit is raw 8-bit application data.
+	Alphabet8Bit
+	// AlphabetUCS2 is the UCS-2 (16-bit) character set.
+	AlphabetUCS2
+)
+
+// encodeDCS renders a as a TP-DCS octet using the general data coding group
+// (3GPP TS 23.038 section 4), with no message class.
+func encodeDCS(a Alphabet) byte {
+	switch a {
+	case Alphabet8Bit:
+		return 0x04
+	case AlphabetUCS2:
+		return 0x08
+	default:
+		return 0x00
+	}
+}
+
+// decodeDCS parses a TP-DCS octet, returning the Alphabet it specifies.
+// Coding groups that this package does not understand are treated as the
+// 7-bit default alphabet, matching common modem behavior.
+func decodeDCS(dcs byte) Alphabet {
+	// General data coding group: bits 7-6 are 00.
+	if dcs&0xC0 == 0x00 {
+		switch (dcs >> 2) & 0x03 {
+		case 1:
+			return Alphabet8Bit
+		case 2:
+			return AlphabetUCS2
+		default:
+			return Alphabet7Bit
+		}
+	}
+
+	return Alphabet7Bit
+}
@@ -0,0 +1,121 @@
+// Package sms implements PDU-mode encoding and decoding of SMS TPDUs, as
+// specified by 3GPP TS 23.040 (message structure) and 3GPP TS 23.038
+// (character encoding), for use by atmodem.Device's SMS methods.
+package sms
+
+import "time"
+
+// A Concat describes a message's position within a concatenated
+// (multi-part) SMS, as carried by a TP-UDH concatenation information
+// element.
+type Concat struct {
+	// Ref identifies which parts belong to the same original message.
+	Ref int
+	// Seq is this part's 1-based position within the message.
+	Seq int
+	// Total is the number of parts the message was split into.
+	Total int
+}
+
+// A Message is a decoded SMS, as returned by an SMS-DELIVER TPDU.
+type Message struct {
+	// Index is the message's storage index, as used by AT+CMGR/AT+CMGD. It
+	// is zero when Message was not read from modem storage.
+	Index int
+	// From is the originating address.
+	From string
+	// Timestamp is the service center timestamp at which the message was
+	// submitted to the network.
+	Timestamp time.Time
+	// Text is the decoded message body.
+	Text string
+	// Concat describes this message's position in a multi-part message, or
+	// is nil if the message was not concatenated.
+	Concat *Concat
+}
+
+// A Filter selects which stored messages AT+CMGL returns, using the <stat>
+// values defined by 3GPP TS 27.005 for PDU mode.
+type Filter int
+
+// Possible Filter values.
+const (
+	FilterUnread Filter = iota
+	FilterRead
+	FilterUnsent
+	FilterSent
+	FilterAll
+)
+
+// String returns the human-readable name of f.
+func (f Filter) String() string {
+	switch f {
+	case FilterUnread:
+		return "unread"
+	case FilterRead:
+		return "read"
+	case FilterUnsent:
+		return "unsent"
+	case FilterSent:
+		return "sent"
+	case FilterAll:
+		return "all"
+	default:
+		return "unknown"
+	}
+}
+
+// A PDU is one hex-encoded TPDU produced by EncodeSubmit, ready to be
+// transmitted following an AT+CMGS prompt.
+type PDU struct {
+	// TPDU is the hex-encoded TPDU, including a leading (possibly
+	// zero-length) SMSC info octet, as expected by AT+CMGS.
+	TPDU string
+	// Octets is the number of TPDU octets TPDU represents, counted from
+	// after the SMSC info prefix, as required by the AT+CMGS length
+	// parameter.
+	Octets int
+}
+
+// sendOptions holds the configuration applied by one or more SendOption
+// values.
+type sendOptions struct {
+	validity    time.Duration
+	hasValidity bool
+	alphabet    Alphabet
+	hasAlphabet bool
+	ref         byte
+	hasRef      bool
+}
+
+// A SendOption configures optional behavior of EncodeSubmit.
+type SendOption func(*sendOptions)
+
+// WithValidity sets the message's relative validity period, after which the
+// network may discard it if not yet delivered.
+func WithValidity(d time.Duration) SendOption {
+	return func(o *sendOptions) {
+		o.validity = d
+		o.hasValidity = true
+	}
+}
+
+// WithAlphabet forces encoding using the specified Alphabet, bypassing the
+// default of automatically choosing the GSM 03.38 default alphabet and
+// falling back to UCS-2 if the message text cannot be represented in it.
+func WithAlphabet(a Alphabet) SendOption {
+	return func(o *sendOptions) {
+		o.alphabet = a
+		o.hasAlphabet = true
+	}
+}
+
+// WithConcatReference overrides the automatically assigned reference number
+// used to tag the parts of a multi-part message, primarily for use in
+// tests.
+func WithConcatReference(ref byte) SendOption {
+	return func(o *sendOptions) {
+		o.ref = ref
+		o.hasRef = true
+	}
+}
@@ -0,0 +1,343 @@
+package sms
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"unicode/utf16"
+)
+
+// Single-part and multi-part (UDH-reduced) capacity limits, in characters or
+// octets depending on alphabet, per 3GPP TS 23.040/23.038.
+const (
+	max7BitSingle = 160
+	max7BitMulti  = 153
+	maxUCS2Single = 70
+	maxUCS2Multi  = 67
+	max8BitSingle = 140
+	max8BitMulti  = 134
+)
+
+// concatRef is a process-lifetime counter used to assign concatenation
+// references to multi-part messages when the caller does not supply one via
+// WithConcatReference.
+var concatRef uint32
+
+// nextConcatRef returns the next automatically assigned concatenation
+// reference.
+func nextConcatRef() byte {
+	return byte(atomic.AddUint32(&concatRef, 1))
+}
+
+// EncodeSubmit encodes an SMS-SUBMIT TPDU (or, if text exceeds a single
+// TPDU's capacity, a sequence of concatenated TPDUs tagged with a shared UDH
+// reference) addressed to dest. By default, text is encoded using the GSM
+// 03.38 default alphabet, falling back to UCS-2 if it contains characters
+// outside that alphabet; use WithAlphabet to override this behavior.
+func EncodeSubmit(dest, text string, opts ...SendOption) ([]PDU, error) {
+	var o sendOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	alphabet := o.alphabet
+	if !o.hasAlphabet {
+		alphabet = Alphabet7Bit
+		if _, err := encodeSeptets(text); err != nil {
+			alphabet = AlphabetUCS2
+		}
+	}
+
+	var uds [][]byte // packed user data (without UDH) per part
+	var udls []int   // TP-UDL value per part, before accounting for a UDH
+	switch alphabet {
+	case Alphabet7Bit:
+		septets, err := encodeSeptets(text)
+		if err != nil {
+			return nil, fmt.Errorf("sms: text cannot be encoded in the GSM 03.38 default alphabet: %w", err)
+		}
+
+		for _, part := range chunkBytes(septets, max7BitSingle, max7BitMulti) {
+			uds = append(uds, part)
+			udls = append(udls, len(part))
+		}
+	case AlphabetUCS2:
+		units := utf16.Encode([]rune(text))
+		for _, part := range chunkUCS2(units, maxUCS2Single, maxUCS2Multi) {
+			b := make([]byte, len(part)*2)
+			for i, u := range part {
+				b[i*2] = byte(u >> 8)
+				b[i*2+1] = byte(u)
+			}
+
+			uds = append(uds, b)
+			udls = append(udls, len(b))
+		}
+	case Alphabet8Bit:
+		for _, part := range chunkBytes([]byte(text), max8BitSingle, max8BitMulti) {
+			uds = append(uds, part)
+			udls = append(udls, len(part))
+		}
+	default:
+		return nil, fmt.Errorf("sms: unsupported alphabet %d", alphabet)
+	}
+
+	dst := Address{Number: dest}.encode()
+
+	multipart := len(uds) > 1
+	ref := o.ref
+	if multipart && !o.hasRef {
+		ref = nextConcatRef()
+	}
+
+	pdus := make([]PDU, 0, len(uds))
+	for i := range uds {
+		var udh []byte
+		if multipart {
+			udh = []byte{0x05, 0x00, 0x03, ref, byte(len(uds)), byte(i + 1)}
+		}
+
+		ud, udl := buildUserData(alphabet, udh, uds[i], udls[i])
+
+		t := []byte{submitFirstOctet(o.hasValidity, len(udh) > 0), 0x00} // TP-MR: let the modem assign.
+		t = append(t, dst...)
+		t = append(t, 0x00) // TP-PID: default.
+		t = append(t, encodeDCS(alphabet))
+		if o.hasValidity {
+			t = append(t, encodeRelativeValidity(o.validity))
+		}
+		t = append(t, byte(udl))
+		t = append(t, ud...)
+
+		// Prefix a zero-length SMSC info field, instructing the modem to use
+		// its currently configured SMSC. AT+CMGS's length parameter counts
+		// only the TPDU octets that follow, not this prefix.
+		full := append([]byte{0x00}, t...)
+		pdus = append(pdus, PDU{TPDU: hex.EncodeToString(full), Octets: len(t)})
+	}
+
+	return pdus, nil
+}
+
+// submitFirstOctet builds the first octet of an SMS-SUBMIT TPDU.
+func submitFirstOctet(hasValidity, hasUDH bool) byte {
+	const mtiSubmit = 0x01
+
+	b := byte(mtiSubmit)
+	if hasValidity {
+		b |= 0x10 // TP-VPF: relative format.
+	}
+	if hasUDH {
+		b |= 0x40 // TP-UDHI: user data header indicator.
+	}
+
+	return b
+}
+
+// buildUserData assembles the TP-UD field from an optional UDH and the
+// already-encoded message body, returning the packed bytes and the TP-UDL
+// value. For the 7-bit alphabet, body holds unpacked septets and udl is a
+// septet count; for 8-bit and UCS-2, body holds raw octets and udl is a byte
+// count.
+func buildUserData(alphabet Alphabet, udh, body []byte, bodyUDL int) (ud []byte, udl int) {
+	if len(udh) == 0 {
+		if alphabet == Alphabet7Bit {
+			return packSeptets(body), bodyUDL
+		}
+
+		return body, bodyUDL
+	}
+
+	if alphabet != Alphabet7Bit {
+		return append(append([]byte{}, udh...), body...), len(udh) + bodyUDL
+	}
+
+	// The UDH is byte-aligned; pad with fill bits so septets begin on the
+	// next septet boundary, per 3GPP TS 23.040 section 9.2.3.24.
+	headerBits := len(udh) * 8
+	fill := (7 - headerBits%7) % 7
+	headerSeptets := (headerBits + fill) / 7
+
+	var w bitWriter
+	for _, b := range udh {
+		w.write(uint32(b), 8)
+	}
+	w.write(0, uint(fill))
+	for _, s := range body {
+		w.write(uint32(s&0x7F), 7)
+	}
+
+	return w.bytes(), headerSeptets + bodyUDL
+}
+
+// chunkBytes splits b into parts no larger than singleMax if it fits in one
+// part, otherwise into parts no larger than multiMax.
+func chunkBytes(b []byte, singleMax, multiMax int) [][]byte {
+	if len(b) <= singleMax {
+		return [][]byte{b}
+	}
+
+	var parts [][]byte
+	for len(b) > 0 {
+		n := multiMax
+		if n > len(b) {
+			n = len(b)
+		}
+
+		parts = append(parts, b[:n])
+		b = b[n:]
+	}
+
+	return parts
+}
+
+// chunkUCS2 splits units into parts no larger than singleMax if it fits in
+// one part, otherwise into parts no larger than multiMax.
+func chunkUCS2(units []uint16, singleMax, multiMax int) [][]uint16 {
+	if len(units) <= singleMax {
+		return [][]uint16{units}
+	}
+
+	var parts [][]uint16
+	for len(units) > 0 {
+		n := multiMax
+		if n > len(units) {
+			n = len(units)
+		}
+
+		parts = append(parts, units[:n])
+		units = units[n:]
+	}
+
+	return parts
+}
+
+// DecodeDeliver parses an SMS-DELIVER TPDU from its hex-encoded wire
+// representation, as returned by AT+CMGR or AT+CMGL. The SMSC address
+// prefix, if present, is skipped.
+func DecodeDeliver(hexPDU string) (*Message, error) {
+	b, err := hex.DecodeString(hexPDU)
+	if err != nil {
+		return nil, fmt.Errorf("sms: malformed PDU hex: %w", err)
+	}
+	if len(b) < 1 {
+		return nil, fmt.Errorf("sms: empty PDU")
+	}
+
+	i := 1 + int(b[0]) // Skip the SMSC address, if any.
+	if i >= len(b) {
+		return nil, fmt.Errorf("sms: PDU too short for SMSC address")
+	}
+
+	first := b[i]
+	i++
+	if first&0x03 != 0x00 {
+		return nil, fmt.Errorf("sms: unsupported TPDU type (not SMS-DELIVER): first octet 0x%02X", first)
+	}
+	hasUDH := first&0x40 != 0
+
+	oa, n, err := decodeAddress(b[i:])
+	if err != nil {
+		return nil, fmt.Errorf("sms: decoding originating address: %w", err)
+	}
+	i += n
+
+	if i+2 > len(b) {
+		return nil, fmt.Errorf("sms: PDU too short for PID/DCS")
+	}
+	// TP-PID is not currently surfaced to callers.
+	i++
+	alphabet := decodeDCS(b[i])
+	i++
+
+	if i+7 > len(b) {
+		return nil, fmt.Errorf("sms: PDU too short for timestamp")
+	}
+	scts, err := decodeTimestamp(b[i : i+7])
+	if err != nil {
+		return nil, fmt.Errorf("sms: decoding timestamp: %w", err)
+	}
+	i += 7
+
+	if i >= len(b) {
+		return nil, fmt.Errorf("sms: PDU too short for user data length")
+	}
+	udl := int(b[i])
+	i++
+
+	ud := b[i:]
+
+	var concat *Concat
+	var text string
+	switch {
+	case hasUDH && len(ud) > 0:
+		udhl := int(ud[0])
+		if 1+udhl > len(ud) {
+			return nil, fmt.Errorf("sms: PDU too short for user data header")
+		}
+
+		header := ud[1 : 1+udhl]
+		concat = parseConcatIE(header)
+
+		if alphabet == Alphabet7Bit {
+			headerBits := (1 + udhl) * 8
+			fill := (7 - headerBits%7) % 7
+			headerSeptets := (headerBits + fill) / 7
+
+			text = decodeSeptets(unpackSeptetsOffset(ud[1+udhl:], udl-headerSeptets, uint(fill)))
+		} else {
+			text = decodeBody(alphabet, ud[1+udhl:])
+		}
+	case alphabet == Alphabet7Bit:
+		text = decodeSeptets(unpackSeptets(ud, udl))
+	default:
+		text = decodeBody(alphabet, ud)
+	}
+
+	return &Message{
+		From:      oa.Number,
+		Timestamp: scts,
+		Text:      text,
+		Concat:    concat,
+	}, nil
+}
+
+// decodeBody decodes a non-7-bit user data body according to alphabet.
+func decodeBody(alphabet Alphabet, b []byte) string {
+	if alphabet == AlphabetUCS2 {
+		units := make([]uint16, len(b)/2)
+		for i := range units {
+			units[i] = uint16(b[i*2])<<8 | uint16(b[i*2+1])
+		}
+
+		return string(utf16.Decode(units))
+	}
+
+	return string(b)
+}
+
+// parseConcatIE looks for an 8-bit (0x00) or 16-bit reference (0x08)
+// concatenation information element within a UDH and returns the resulting
+// Concat, or nil if none is present.
+func parseConcatIE(header []byte) *Concat {
+	for i := 0; i+1 < len(header); {
+		iei := header[i]
+		iedl := int(header[i+1])
+		start := i + 2
+		if start+iedl > len(header) {
+			return nil
+		}
+		ied := header[start : start+iedl]
+
+		switch {
+		case iei == 0x00 && iedl == 3:
+			return &Concat{Ref: int(ied[0]), Total: int(ied[1]), Seq: int(ied[2])}
+		case iei == 0x08 && iedl == 4:
+			return &Concat{Ref: int(ied[0])<<8 | int(ied[1]), Total: int(ied[2]), Seq: int(ied[3])}
+		}
+
+		i = start + iedl
+	}
+
+	return nil
+}
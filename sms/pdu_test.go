@@ -0,0 +1,201 @@
+package sms
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncodeSubmit(t *testing.T) {
+	tests := []struct {
+		name      string
+		dest      string
+		text      string
+		opts      []SendOption
+		wantParts int
+	}{
+		{
+			name:      "short 7-bit message",
+			dest:      "+15551234567",
+			text:      "Hello, World!",
+			wantParts: 1,
+		},
+		{
+			name:      "exactly one part",
+			dest:      "+15551234567",
+			text:      repeatString("A", max7BitSingle),
+			wantParts: 1,
+		},
+		{
+			name:      "requires concatenation",
+			dest:      "+15551234567",
+			text:      repeatString("A", max7BitSingle+1),
+			wantParts: 2,
+		},
+		{
+			name:      "two full multi-part segments",
+			dest:      "+15551234567",
+			text:      repeatString("A", max7BitMulti*2),
+			wantParts: 2,
+		},
+		{
+			name:      "forced UCS-2",
+			dest:      "+15551234567",
+			text:      "plain ascii",
+			opts:      []SendOption{WithAlphabet(AlphabetUCS2)},
+			wantParts: 1,
+		},
+		{
+			name:      "auto UCS-2 fallback",
+			dest:      "+15551234567",
+			text:      "hi 世界",
+			wantParts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pdus, err := EncodeSubmit(tt.dest, tt.text, tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to encode submit: %v", err)
+			}
+			if len(pdus) != tt.wantParts {
+				t.Fatalf("unexpected part count: got %d, want %d", len(pdus), tt.wantParts)
+			}
+
+			for _, p := range pdus {
+				b, err := hex.DecodeString(p.TPDU)
+				if err != nil {
+					t.Fatalf("TPDU is not valid hex: %v", err)
+				}
+				if len(b) != p.Octets+1 {
+					// +1 for the zero-length SMSC info prefix.
+					t.Fatalf("unexpected TPDU length: got %d bytes, Octets=%d", len(b), p.Octets)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeSubmitUnencodable(t *testing.T) {
+	if _, err := EncodeSubmit("+15551234567", "hi 世界", WithAlphabet(Alphabet7Bit)); err == nil {
+		t.Fatal("expected an error forcing an unencodable alphabet, but none occurred")
+	}
+}
+
+func TestDecodeDeliverRoundTrip(t *testing.T) {
+	septets, err := encodeSeptets("Hello there!")
+	if err != nil {
+		t.Fatalf("failed to encode septets: %v", err)
+	}
+
+	tpdu := buildDeliverTPDU(t, "+15555550123", nil, septets, len(septets))
+
+	msg, err := DecodeDeliver(hex.EncodeToString(tpdu))
+	if err != nil {
+		t.Fatalf("failed to decode deliver: %v", err)
+	}
+
+	want := &Message{
+		From:      "+15555550123",
+		Timestamp: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.FixedZone("", 0)),
+		Text:      "Hello there!",
+	}
+
+	if diff := cmp.Diff(want, msg); diff != "" {
+		t.Fatalf("unexpected message (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeDeliverConcatenated(t *testing.T) {
+	septets, err := encodeSeptets("second part")
+	if err != nil {
+		t.Fatalf("failed to encode septets: %v", err)
+	}
+
+	udh := []byte{0x05, 0x00, 0x03, 0x2A, 0x02, 0x02}
+	headerBits := len(udh) * 8
+	fill := (7 - headerBits%7) % 7
+	headerSeptets := (headerBits + fill) / 7
+
+	var w bitWriter
+	for _, b := range udh {
+		w.write(uint32(b), 8)
+	}
+	w.write(0, uint(fill))
+	for _, s := range septets {
+		w.write(uint32(s&0x7F), 7)
+	}
+
+	tpdu := buildDeliverTPDU(t, "+15555550123", udh, w.bytes(), headerSeptets+len(septets))
+
+	msg, err := DecodeDeliver(hex.EncodeToString(tpdu))
+	if err != nil {
+		t.Fatalf("failed to decode deliver: %v", err)
+	}
+
+	want := &Concat{Ref: 0x2A, Total: 2, Seq: 2}
+	if diff := cmp.Diff(want, msg.Concat); diff != "" {
+		t.Fatalf("unexpected concat info (-want +got):\n%s", diff)
+	}
+	if msg.Text != "second part" {
+		t.Fatalf("unexpected text: got %q", msg.Text)
+	}
+}
+
+// buildDeliverTPDU hand-assembles a minimal SMS-DELIVER TPDU (including a
+// zero-length SMSC info prefix) for use in DecodeDeliver tests. If udh is
+// non-nil, ud must already contain the raw packed bytes including the UDH;
+// otherwise ud holds unpacked 7-bit septets.
+func buildDeliverTPDU(t *testing.T, from string, udh, ud []byte, udl int) []byte {
+	t.Helper()
+
+	first := byte(0x00) // MTI = SMS-DELIVER.
+	if udh != nil {
+		first |= 0x40
+	}
+
+	b := []byte{0x00, first} // SMSC info (none) + first octet.
+	b = append(b, Address{Number: from}.encode()...)
+	b = append(b, 0x00)                    // TP-PID.
+	b = append(b, encodeDCS(Alphabet7Bit)) // TP-DCS.
+	b = append(b, timestampBytes(2024, 1, 2, 3, 4, 5, 0, false)...)
+	b = append(b, byte(udl))
+
+	if udh == nil {
+		ud = packSeptets(ud)
+	}
+	b = append(b, ud...)
+
+	return b
+}
+
+// timestampBytes encodes a TP-SCTS timestamp for use in tests, mirroring
+// decodeTimestamp.
+func timestampBytes(year, month, day, hour, min, sec, tzQuarterHours int, negative bool) []byte {
+	bcd := func(v int) byte {
+		return byte(v/10) | byte(v%10)<<4
+	}
+
+	tz := bcd(tzQuarterHours)
+	if negative {
+		tz |= 0x08
+	}
+
+	return []byte{
+		bcd(year % 100), bcd(month), bcd(day),
+		bcd(hour), bcd(min), bcd(sec),
+		tz,
+	}
+}
+
+// repeatString returns s repeated until it reaches length n.
+func repeatString(s string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = s[0]
+	}
+	return string(b)
+}
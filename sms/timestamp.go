@@ -0,0 +1,70 @@
+package sms
+
+import (
+	"fmt"
+	"time"
+)
+
+// decodeTimestamp parses a 7-octet TP-SCTS service center timestamp, per
+// 3GPP TS 23.040 section 9.2.3.11. Each field is a semi-octet swapped BCD
+// pair; the final octet encodes the timezone offset from UTC in 15 minute
+// increments, with bit 3 (0x08), the high bit of its tens digit, indicating a
+// negative offset.
+func decodeTimestamp(b []byte) (time.Time, error) {
+	if len(b) < 7 {
+		return time.Time{}, fmt.Errorf("sms: short timestamp: %d bytes", len(b))
+	}
+
+	year, err := bcdDigit(b[0])
+	if err != nil {
+		return time.Time{}, err
+	}
+	month, err := bcdDigit(b[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	day, err := bcdDigit(b[2])
+	if err != nil {
+		return time.Time{}, err
+	}
+	hour, err := bcdDigit(b[3])
+	if err != nil {
+		return time.Time{}, err
+	}
+	min, err := bcdDigit(b[4])
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, err := bcdDigit(b[5])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	tzOctet := b[6]
+	negative := tzOctet&0x08 != 0
+	tzOctet &^= 0x08
+	quarterHours, err := bcdDigit(tzOctet)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	offset := quarterHours * 15 * 60
+	if negative {
+		offset = -offset
+	}
+
+	loc := time.FixedZone("", offset)
+	return time.Date(2000+year, time.Month(month), day, hour, min, sec, 0, loc), nil
+}
+
+// bcdDigit decodes a single semi-octet swapped BCD byte, whose low nibble
+// holds the tens digit, into its two-digit decimal value.
+func bcdDigit(b byte) (int, error) {
+	lo := b & 0x0F
+	hi := b >> 4
+	if lo > 9 || hi > 9 {
+		return 0, fmt.Errorf("sms: invalid BCD octet 0x%02X", b)
+	}
+
+	return int(lo)*10 + int(hi), nil
+}
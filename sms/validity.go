@@ -0,0 +1,68 @@
+package sms
+
+import "time"
+
+// encodeRelativeValidity renders d as a TP-VP relative validity period octet,
+// per 3GPP TS 23.040 section 9.2.3.12.1. Durations that fall between two
+// representable values round up to the next coarser granularity.
+func encodeRelativeValidity(d time.Duration) byte {
+	switch {
+	case d <= 12*time.Hour:
+		n := ceilDiv(d, 5*time.Minute)
+		if n < 1 {
+			n = 1
+		}
+		if n > 144 {
+			n = 144
+		}
+		return byte(n - 1)
+	case d <= 24*time.Hour:
+		n := ceilDiv(d-12*time.Hour, 30*time.Minute)
+		vp := 143 + n
+		if vp > 167 {
+			vp = 167
+		}
+		return byte(vp)
+	case d <= 30*24*time.Hour:
+		n := ceilDiv(d, 24*time.Hour)
+		vp := 166 + n
+		if vp > 196 {
+			vp = 196
+		}
+		return byte(vp)
+	default:
+		n := ceilDiv(d, 7*24*time.Hour)
+		if n < 5 {
+			n = 5
+		}
+		vp := 192 + n
+		if vp > 255 {
+			vp = 255
+		}
+		return byte(vp)
+	}
+}
+
+// decodeRelativeValidity parses a TP-VP relative validity period octet into
+// an approximate time.Duration.
+func decodeRelativeValidity(vp byte) time.Duration {
+	switch {
+	case vp <= 143:
+		return time.Duration(vp+1) * 5 * time.Minute
+	case vp <= 167:
+		return 12*time.Hour + time.Duration(vp-143)*30*time.Minute
+	case vp <= 196:
+		return time.Duration(vp-166) * 24 * time.Hour
+	default:
+		return time.Duration(vp-192) * 7 * 24 * time.Hour
+	}
+}
+
+// ceilDiv divides d by unit, rounding up.
+func ceilDiv(d, unit time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+
+	return int((d + unit - 1) / unit)
+}
@@ -0,0 +1,32 @@
+package sms
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_relativeValidityRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want time.Duration
+	}{
+		{name: "five minutes", d: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "one hour", d: time.Hour, want: time.Hour},
+		{name: "twelve hours", d: 12 * time.Hour, want: 12 * time.Hour},
+		{name: "one day", d: 24 * time.Hour, want: 24 * time.Hour},
+		{name: "one week", d: 7 * 24 * time.Hour, want: 7 * 24 * time.Hour},
+		{name: "sixty days", d: 60 * 24 * time.Hour, want: 63 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := encodeRelativeValidity(tt.d)
+			got := decodeRelativeValidity(vp)
+
+			if got != tt.want {
+				t.Fatalf("unexpected validity: got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package atmodem_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/atmodem"
+	"github.com/mdlayher/atmodem/atmodemtest"
+	"github.com/mdlayher/atmodem/sms"
+)
+
+// TPDU encode/decode correctness is covered by the sms package's own tests;
+// this only exercises Device's AT command plumbing.
+func TestDeviceDeleteSMS(t *testing.T) {
+	withDevice(t, "OK", true, []byte("AT+CMGD=4\r\n"), func(d *atmodem.Device) error {
+		return d.DeleteSMS(context.Background(), 4)
+	})
+}
+
+func TestDeviceSelectSMSStorage(t *testing.T) {
+	withDevice(t, "OK", true, []byte(`AT+CPMS="SM","SM","SM"`+"\r\n"), func(d *atmodem.Device) error {
+		return d.SelectSMSStorage(context.Background(), "SM")
+	})
+}
+
+// TPDU encode/decode correctness is covered by the sms package's own tests;
+// these only exercise Device's AT command plumbing: the CMGF mode-select
+// sequencing and the per-part AT+CMGS framing.
+func TestDeviceSendSMS(t *testing.T) {
+	t.Run("single part", func(t *testing.T) {
+		const dest = "+15551234567"
+		const text = "Hello, World!"
+
+		pdus, err := sms.EncodeSubmit(dest, text)
+		if err != nil {
+			t.Fatalf("failed to encode submit: %v", err)
+		}
+		if len(pdus) != 1 {
+			t.Fatalf("unexpected part count: got %d, want 1", len(pdus))
+		}
+
+		var wantWritten bytes.Buffer
+		wantWritten.WriteString("AT+CMGF=0\r\n")
+		fmt.Fprintf(&wantWritten, "AT+CMGS=%d\r%s\x1a\r\n", pdus[0].Octets, pdus[0].TPDU)
+
+		fake := atmodemtest.New("OK", "+CMGS: 42\n\nOK")
+		d, err := atmodem.Open(fake, 1*time.Second)
+		if err != nil {
+			t.Fatalf("failed to open device: %v", err)
+		}
+		defer d.Close()
+
+		ref, err := d.SendSMS(context.Background(), dest, text)
+		if err != nil {
+			t.Fatalf("failed to send SMS: %v", err)
+		}
+		if ref != 42 {
+			t.Fatalf("unexpected message reference: got %d, want 42", ref)
+		}
+
+		if diff := cmp.Diff(wantWritten.Bytes(), fake.Written()); diff != "" {
+			t.Fatalf("unexpected modem commands (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("multipart concatenated", func(t *testing.T) {
+		const dest = "+15551234567"
+		text := repeatString("A", 200) // Exceeds a single 7-bit part.
+
+		opts := []sms.SendOption{sms.WithConcatReference(7)}
+
+		pdus, err := sms.EncodeSubmit(dest, text, opts...)
+		if err != nil {
+			t.Fatalf("failed to encode submit: %v", err)
+		}
+		if len(pdus) < 2 {
+			t.Fatalf("expected a multi-part message, got %d part(s)", len(pdus))
+		}
+
+		var wantWritten bytes.Buffer
+		wantWritten.WriteString("AT+CMGF=0\r\n")
+
+		responses := []string{"OK"}
+		for i, p := range pdus {
+			fmt.Fprintf(&wantWritten, "AT+CMGS=%d\r%s\x1a\r\n", p.Octets, p.TPDU)
+			responses = append(responses, fmt.Sprintf("+CMGS: %d\n\nOK", 100+i))
+		}
+
+		fake := atmodemtest.New(responses...)
+		d, err := atmodem.Open(fake, 1*time.Second)
+		if err != nil {
+			t.Fatalf("failed to open device: %v", err)
+		}
+		defer d.Close()
+
+		ref, err := d.SendSMS(context.Background(), dest, text, opts...)
+		if err != nil {
+			t.Fatalf("failed to send SMS: %v", err)
+		}
+		if want := 100 + len(pdus) - 1; ref != want {
+			t.Fatalf("unexpected final message reference: got %d, want %d", ref, want)
+		}
+
+		if diff := cmp.Diff(wantWritten.Bytes(), fake.Written()); diff != "" {
+			t.Fatalf("unexpected modem commands (-want +got):\n%s", diff)
+		}
+	})
+}
+
+// repeatString returns s repeated until it reaches length n.
+func repeatString(s string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = s[0]
+	}
+	return string(b)
+}
+
+func TestDeviceListSMS(t *testing.T) {
+	// Pre-encoded SMS-DELIVER TPDUs (zero-length SMSC prefix included), one
+	// from each of two originators. TPDU encode/decode correctness is
+	// covered by the sms package's own tests.
+	const pdu1 = "00000b915155550521f30000421020304050000de6b47c4e07b5cbf379f85c06"
+	const pdu2 = "00000b915155550591f90000421020304050000ef3f2f8ed2683dae5f93c7c2e03"
+
+	res := fmt.Sprintf("+CMGL: 1,1,,%d\n%s\n+CMGL: 2,1,,%d\n%s\n\nOK",
+		len(pdu1)/2, pdu1, len(pdu2)/2, pdu2)
+
+	fake := atmodemtest.New("OK", res)
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	msgs, err := d.ListSMS(context.Background(), sms.FilterAll)
+	if err != nil {
+		t.Fatalf("failed to list SMS: %v", err)
+	}
+
+	ts := time.Date(2024, time.January, 2, 3, 4, 5, 0, time.FixedZone("", 0))
+	want := []sms.Message{
+		{Index: 1, From: "+15555550123", Timestamp: ts, Text: "first message"},
+		{Index: 2, From: "+15555550199", Timestamp: ts, Text: "second message"},
+	}
+
+	if diff := cmp.Diff(want, msgs); diff != "" {
+		t.Fatalf("unexpected messages (-want +got):\n%s", diff)
+	}
+
+	wantWritten := []byte("AT+CMGF=0\r\nAT+CMGL=4\r\n")
+	if diff := cmp.Diff(wantWritten, fake.Written()); diff != "" {
+		t.Fatalf("unexpected modem commands (-want +got):\n%s", diff)
+	}
+}
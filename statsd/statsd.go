@@ -0,0 +1,71 @@
+// Package statsd adapts atmodem.MetricsSink to the plaintext StatsD wire
+// protocol, emitting gauges and counters as UDP datagrams.
+package statsd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// An Emitter is an atmodem.MetricsSink which writes metrics to a StatsD
+// server over UDP. Labels are appended to the metric name as a dot-joined
+// "key-value" tag suffix, since plain StatsD has no native concept of
+// labels.
+type Emitter struct {
+	conn net.Conn
+}
+
+// New dials addr (a "host:port" UDP address) and returns an Emitter ready
+// for use with Device.WithMetrics.
+func New(addr string) (*Emitter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("statsd: failed to dial %q: %w", addr, err)
+	}
+
+	return &Emitter{conn: conn}, nil
+}
+
+// Close closes the underlying UDP connection.
+func (e *Emitter) Close() error { return e.conn.Close() }
+
+// Gauge implements atmodem.MetricsSink.
+func (e *Emitter) Gauge(name string, value float64, labels map[string]string) {
+	e.send(fmt.Sprintf("%s:%v|g", tagName(name, labels), value))
+}
+
+// Counter implements atmodem.MetricsSink.
+func (e *Emitter) Counter(name string, delta uint64, labels map[string]string) {
+	e.send(fmt.Sprintf("%s:%d|c", tagName(name, labels), delta))
+}
+
+// send writes line to the StatsD server, silently dropping it on failure
+// since metrics publication must never block or fail the caller.
+func (e *Emitter) send(line string) {
+	_, _ = e.conn.Write([]byte(line))
+}
+
+// tagName appends labels to name as a sorted, dot-joined "key-value" tag
+// suffix so that repeated calls with equivalent labels produce identical
+// metric names.
+func tagName(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		fmt.Fprintf(&b, ".%s-%s", k, labels[k])
+	}
+
+	return b.String()
+}
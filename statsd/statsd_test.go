@@ -0,0 +1,32 @@
+package statsd
+
+import "testing"
+
+func Test_tagName(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "no labels",
+			metric: "atmodem_commands_total",
+			want:   "atmodem_commands_total",
+		},
+		{
+			name:   "labels sorted regardless of input order",
+			metric: "atmodem_commands_total",
+			labels: map[string]string{"result": "ok", "command": "+CSQ"},
+			want:   "atmodem_commands_total.command-+CSQ.result-ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagName(tt.metric, tt.labels); got != tt.want {
+				t.Fatalf("unexpected tag name: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package atmodem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status contains the modem's current radio status, as reported by the
+// Sierra Wireless vendor command !GSTATUS?.
+type Status struct {
+	CurrentTime                           time.Duration
+	Temperature, ResetCounter             int
+	Mode, SystemMode, PSState, LTEBand    string
+	LTEBandwidthMHz                       float64
+	LTEReceiveChannel, LTETransmitChannel int
+	LTECAState                            string
+	EMMState, EMMSubstate                 string
+	RRCState, IMSRegState                 string
+	PCCRXMRSSI, RSRPRXMdBm                int
+	PCCRXDRSSI, RSRPRXDdBm                int
+	// TransmitPower is nil if the modem is not currently transmitting.
+	TransmitPower  *int
+	TAC            string
+	TACDecimal     int
+	CellID         string
+	CellIDDecimal  int
+	RSRQdB, SINRdB float64
+}
+
+// ErrUnknownField is returned by Status when the modem returns a response
+// line which does not match any schema in gstatusSchema and the Device is
+// configured via StatusStrict(true).
+var ErrUnknownField = errors.New("atmodem: unknown status field")
+
+// StatusStrict configures whether Status returns ErrUnknownField when it
+// encounters a response line it cannot parse. By default, Status silently
+// ignores unrecognized lines so that minor vendor firmware differences
+// don't break callers who only care about a subset of fields.
+func (d *Device) StatusStrict(strict bool) {
+	d.mu.Lock()
+	d.statusStrict = strict
+	d.mu.Unlock()
+}
+
+// Status returns the current status of the modem.
+func (d *Device) Status(ctx context.Context) (*Status, error) {
+	ss, err := d.command(ctx, "!GSTATUS?")
+	if err != nil {
+		return nil, err
+	}
+	if len(ss) == 0 {
+		return nil, errors.New("atmodem: empty status response from modem")
+	}
+
+	d.mu.Lock()
+	strict := d.statusStrict
+	d.mu.Unlock()
+
+	s, err := parseStatus(ss, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	d.publishStatusMetrics(ctx, s)
+	return s, nil
+}
+
+// A FieldSchema recognizes and parses one or two key/value pairs from a
+// single line of a vendor status response, such as the output of
+// !GSTATUS?.
+type FieldSchema struct {
+	// Key names the schema for diagnostic purposes, such as in an
+	// ErrUnknownField error.
+	Key string
+	// Pattern matches an entire trimmed response line, capturing the
+	// substrings Assign needs to populate Status.
+	Pattern *regexp.Regexp
+	// Assign parses Pattern's capture groups (excluding the full match) into
+	// s.
+	Assign func(s *Status, groups []string) error
+}
+
+// parseStatus unpacks a Status structure from a !GSTATUS? response by
+// matching each line against gstatusSchema. If strict is true, a line which
+// matches no schema causes parseStatus to return ErrUnknownField; otherwise
+// the line is silently ignored.
+func parseStatus(lines []string, strict bool) (*Status, error) {
+	var s Status
+	for i, l := range lines {
+		if i == 0 {
+			// Skip the !GSTATUS: response header.
+			continue
+		}
+
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+
+		matched := false
+		for _, fs := range gstatusSchema {
+			groups := fs.Pattern.FindStringSubmatch(l)
+			if groups == nil {
+				continue
+			}
+
+			if err := fs.Assign(&s, groups[1:]); err != nil {
+				return nil, fmt.Errorf("atmodem: failed to parse %s: %w", fs.Key, err)
+			}
+
+			matched = true
+			break
+		}
+
+		if !matched && strict {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, l)
+		}
+	}
+
+	return &s, nil
+}
+
+// gstatusSchema describes the MC7455's !GSTATUS? response format, pairing
+// each line shape with the Status fields it populates. Pairing the two
+// key/value pairs that share a line directly in a single schema removes the
+// need to disambiguate the two "RSRP (dBm):" occurrences by parser state,
+// since each is matched alongside the "PCC RxM"/"PCC RxD" RSSI it always
+// accompanies.
+var gstatusSchema = []FieldSchema{
+	{
+		Key:     "Current Time / Temperature",
+		Pattern: regexp.MustCompile(`^Current Time:\s*(\d+)\s+Temperature:\s*(-?\d+)$`),
+		Assign: func(s *Status, g []string) error {
+			secs, err := strconv.Atoi(g[0])
+			if err != nil {
+				return err
+			}
+			s.CurrentTime = time.Duration(secs) * time.Second
+
+			temp, err := strconv.Atoi(g[1])
+			if err != nil {
+				return err
+			}
+			s.Temperature = temp
+			return nil
+		},
+	},
+	{
+		Key:     "Reset Counter / Mode",
+		Pattern: regexp.MustCompile(`^Reset Counter:\s*(\d+)\s+Mode:\s*(\S+)$`),
+		Assign: func(s *Status, g []string) error {
+			n, err := strconv.Atoi(g[0])
+			if err != nil {
+				return err
+			}
+			s.ResetCounter = n
+			s.Mode = g[1]
+			return nil
+		},
+	},
+	{
+		Key:     "System mode / PS state",
+		Pattern: regexp.MustCompile(`^System mode:\s*(\S+)\s+PS state:\s*(\S+)$`),
+		Assign: func(s *Status, g []string) error {
+			s.SystemMode = g[0]
+			s.PSState = g[1]
+			return nil
+		},
+	},
+	{
+		Key:     "LTE band / LTE bw",
+		Pattern: regexp.MustCompile(`^LTE band:\s*(\S+)\s+LTE bw:\s*([\d.]+)\s*MHz$`),
+		Assign: func(s *Status, g []string) error {
+			s.LTEBand = g[0]
+
+			bw, err := strconv.ParseFloat(g[1], 64)
+			if err != nil {
+				return err
+			}
+			s.LTEBandwidthMHz = bw
+			return nil
+		},
+	},
+	{
+		Key:     "LTE Rx chan / LTE Tx chan",
+		Pattern: regexp.MustCompile(`^LTE Rx chan:\s*(\d+)\s+LTE Tx chan:\s*(\d+)$`),
+		Assign: func(s *Status, g []string) error {
+			rx, err := strconv.Atoi(g[0])
+			if err != nil {
+				return err
+			}
+			tx, err := strconv.Atoi(g[1])
+			if err != nil {
+				return err
+			}
+			s.LTEReceiveChannel = rx
+			s.LTETransmitChannel = tx
+			return nil
+		},
+	},
+	{
+		Key:     "LTE CA state",
+		Pattern: regexp.MustCompile(`^LTE CA state:\s*(.+)$`),
+		Assign: func(s *Status, g []string) error {
+			s.LTECAState = g[0]
+			return nil
+		},
+	},
+	{
+		Key:     "EMM state",
+		Pattern: regexp.MustCompile(`^EMM state:\s*(\S+)\s+(.+)$`),
+		Assign: func(s *Status, g []string) error {
+			s.EMMState = g[0]
+			s.EMMSubstate = g[1]
+			return nil
+		},
+	},
+	{
+		Key:     "RRC state",
+		Pattern: regexp.MustCompile(`^RRC state:\s*(.+)$`),
+		Assign: func(s *Status, g []string) error {
+			s.RRCState = g[0]
+			return nil
+		},
+	},
+	{
+		Key:     "IMS reg state",
+		Pattern: regexp.MustCompile(`^IMS reg state:\s*(.+)$`),
+		Assign: func(s *Status, g []string) error {
+			s.IMSRegState = g[0]
+			return nil
+		},
+	},
+	{
+		Key:     "PCC RxM RSSI / RSRP",
+		Pattern: regexp.MustCompile(`^PCC RxM RSSI:\s*(-?\d+)\s+RSRP \(dBm\):\s*(-?\d+)$`),
+		Assign: func(s *Status, g []string) error {
+			rssi, err := strconv.Atoi(g[0])
+			if err != nil {
+				return err
+			}
+			rsrp, err := strconv.Atoi(g[1])
+			if err != nil {
+				return err
+			}
+			s.PCCRXMRSSI = rssi
+			s.RSRPRXMdBm = rsrp
+			return nil
+		},
+	},
+	{
+		Key:     "PCC RxD RSSI / RSRP",
+		Pattern: regexp.MustCompile(`^PCC RxD RSSI:\s*(-?\d+)\s+RSRP \(dBm\):\s*(-?\d+)$`),
+		Assign: func(s *Status, g []string) error {
+			rssi, err := strconv.Atoi(g[0])
+			if err != nil {
+				return err
+			}
+			rsrp, err := strconv.Atoi(g[1])
+			if err != nil {
+				return err
+			}
+			s.PCCRXDRSSI = rssi
+			s.RSRPRXDdBm = rsrp
+			return nil
+		},
+	},
+	{
+		Key:     "Tx Power / TAC",
+		Pattern: regexp.MustCompile(`^Tx Power:\s*(\S+)\s+TAC:\s*([0-9A-Fa-f]+)\s*\((\d+)\)$`),
+		Assign: func(s *Status, g []string) error {
+			if g[0] != "--" {
+				tx, err := strconv.Atoi(g[0])
+				if err != nil {
+					return err
+				}
+				s.TransmitPower = &tx
+			}
+
+			dec, err := strconv.Atoi(g[2])
+			if err != nil {
+				return err
+			}
+			s.TAC = g[1]
+			s.TACDecimal = dec
+			return nil
+		},
+	},
+	{
+		Key:     "RSRQ (dB) / Cell ID",
+		Pattern: regexp.MustCompile(`^RSRQ \(dB\):\s*(-?[\d.]+)\s+Cell ID:\s*([0-9A-Fa-f]+)\s*\((\d+)\)$`),
+		Assign: func(s *Status, g []string) error {
+			rsrq, err := strconv.ParseFloat(g[0], 64)
+			if err != nil {
+				return err
+			}
+
+			dec, err := strconv.Atoi(g[2])
+			if err != nil {
+				return err
+			}
+			s.RSRQdB = rsrq
+			s.CellID = g[1]
+			s.CellIDDecimal = dec
+			return nil
+		},
+	},
+	{
+		Key:     "SINR (dB)",
+		Pattern: regexp.MustCompile(`^SINR \(dB\):\s*(-?[\d.]+)$`),
+		Assign: func(s *Status, g []string) error {
+			sinr, err := strconv.ParseFloat(g[0], 64)
+			if err != nil {
+				return err
+			}
+			s.SINRdB = sinr
+			return nil
+		},
+	},
+}
@@ -0,0 +1,157 @@
+package atmodem
+
+import "strings"
+
+// A URC is an Unsolicited Result Code: a line emitted by the modem
+// asynchronously, outside of the request/response flow used by Command.
+type URC struct {
+	// Prefix is the registered prefix that matched this URC, such as "+CREG"
+	// or "!AVIND".
+	Prefix string
+	// Raw is the raw, unparsed line received from the modem.
+	Raw string
+	// Fields parses the values following Prefix using the same valueParser
+	// helpers used by parseInfo and the typed command parsers.
+	Fields *valueParser
+}
+
+// OnURC registers fn to be invoked whenever the modem emits a URC whose line
+// begins with prefix, such as "+CREG" or "!AVIND". The returned cancel
+// function removes the registration; it is safe to call more than once.
+//
+// OnURC returns an error, without registering fn, if prefix is already
+// registered — directly via a prior OnURC call, or indirectly via
+// OnNetworkRegistration or OnSMSNotification.
+func (d *Device) OnURC(prefix string, fn func(URC)) (cancel func(), err error) {
+	if err := d.d.AddIndication(prefix, func(lines []string) {
+		fn(newURC(prefix, lines))
+	}); err != nil {
+		return nil, err
+	}
+
+	return func() { d.d.CancelIndication(prefix) }, nil
+}
+
+// newURC builds a URC from the lines delivered for an indication matching
+// prefix.
+func newURC(prefix string, lines []string) URC {
+	raw := strings.Join(lines, "\n")
+
+	fields, err := splitFields(raw)
+	vp, verr := newValueParser(fields)
+	if verr != nil {
+		if err == nil {
+			err = verr
+		}
+
+		// No fields to parse; preserve the error so Fields.Err reports it
+		// rather than the caller dereferencing a nil valueParser.
+		vp = &valueParser{err: err}
+	}
+
+	return URC{
+		Prefix: prefix,
+		Raw:    raw,
+		Fields: vp,
+	}
+}
+
+// A NetworkRegistration describes a +CREG/+CGREG/+CEREG unsolicited
+// registration status change.
+type NetworkRegistration struct {
+	Stat    int
+	LAC, CI string
+	AcT     int
+}
+
+// OnNetworkRegistration registers fn to be invoked whenever the modem reports
+// a change in circuit-switched, packet-switched, or EPS network registration
+// status via +CREG, +CGREG, or +CEREG.
+//
+// If any of the three prefixes is already registered — directly via OnURC,
+// or by a prior OnNetworkRegistration call — OnNetworkRegistration cancels
+// any of the three it already registered and returns the error.
+func (d *Device) OnNetworkRegistration(fn func(NetworkRegistration)) (cancel func(), err error) {
+	var cancels []func()
+	for _, prefix := range []string{"+CREG", "+CGREG", "+CEREG"} {
+		c, err := d.OnURC(prefix, func(u URC) {
+			fn(parseNetworkRegistration(u))
+		})
+		if err != nil {
+			for _, c := range cancels {
+				c()
+			}
+
+			return nil, err
+		}
+
+		cancels = append(cancels, c)
+	}
+
+	return func() {
+		for _, c := range cancels {
+			c()
+		}
+	}, nil
+}
+
+// parseNetworkRegistration unpacks a NetworkRegistration from a URC's fields.
+func parseNetworkRegistration(u URC) NetworkRegistration {
+	fields, err := splitFields(u.Raw)
+	if err != nil {
+		return NetworkRegistration{}
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return NetworkRegistration{}
+	}
+
+	reg := NetworkRegistration{Stat: vp.IntAt(0)}
+	if len(fields) > 1 {
+		reg.LAC = vp.QuotedStringAt(1)
+	}
+	if len(fields) > 2 {
+		reg.CI = vp.QuotedStringAt(2)
+	}
+	if len(fields) > 3 {
+		reg.AcT = vp.IntAt(3)
+	}
+
+	return reg
+}
+
+// An SMSNotification describes a +CMTI unsolicited notification that a new
+// SMS message has arrived in modem storage.
+type SMSNotification struct {
+	Memory string
+	Index  int
+}
+
+// OnSMSNotification registers fn to be invoked whenever the modem reports the
+// arrival of a new SMS message via +CMTI.
+func (d *Device) OnSMSNotification(fn func(SMSNotification)) (cancel func(), err error) {
+	return d.OnURC("+CMTI", func(u URC) {
+		fn(parseSMSNotification(u))
+	})
+}
+
+// parseSMSNotification unpacks an SMSNotification from a URC's fields.
+func parseSMSNotification(u URC) SMSNotification {
+	fields, err := splitFields(u.Raw)
+	if err != nil {
+		return SMSNotification{}
+	}
+
+	vp, err := newValueParser(fields)
+	if err != nil {
+		return SMSNotification{}
+	}
+
+	n := SMSNotification{Memory: vp.QuotedStringAt(0)}
+	if len(fields) > 1 {
+		n.Index = vp.IntAt(1)
+	}
+
+	return n
+}
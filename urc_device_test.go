@@ -0,0 +1,66 @@
+package atmodem_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mdlayher/atmodem"
+	"github.com/mdlayher/atmodem/atmodemtest"
+)
+
+// TestDeviceOnNetworkRegistration verifies that a handler registered via
+// OnNetworkRegistration actually fires when the modem emits a matching URC,
+// exercising the AddIndication/CancelIndication wiring end-to-end rather
+// than just the parseNetworkRegistration helper.
+func TestDeviceOnNetworkRegistration(t *testing.T) {
+	fake := atmodemtest.New("OK")
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	regC := make(chan atmodem.NetworkRegistration, 1)
+	cancel, err := d.OnNetworkRegistration(func(reg atmodem.NetworkRegistration) {
+		regC <- reg
+	})
+	if err != nil {
+		t.Fatalf("failed to register handler: %v", err)
+	}
+	defer cancel()
+
+	fake.Push(`+CEREG: 5,"1A2B","0C3D44E",7`)
+
+	select {
+	case reg := <-regC:
+		want := atmodem.NetworkRegistration{Stat: 5, LAC: "1A2B", CI: "0C3D44E", AcT: 7}
+		if diff := cmp.Diff(want, reg); diff != "" {
+			t.Fatalf("unexpected registration (-want +got):\n%s", diff)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for URC handler to fire")
+	}
+}
+
+// TestDeviceOnURCDuplicatePrefix verifies that registering a second handler
+// for a prefix already registered returns an error rather than silently
+// discarding the later handler.
+func TestDeviceOnURCDuplicatePrefix(t *testing.T) {
+	fake := atmodemtest.New("OK")
+	d, err := atmodem.Open(fake, 1*time.Second)
+	if err != nil {
+		t.Fatalf("failed to open device: %v", err)
+	}
+	defer d.Close()
+
+	cancel, err := d.OnURC("+CREG", func(atmodem.URC) {})
+	if err != nil {
+		t.Fatalf("failed to register handler: %v", err)
+	}
+	defer cancel()
+
+	if _, err := d.OnURC("+CREG", func(atmodem.URC) {}); err == nil {
+		t.Fatal("expected an error registering a duplicate prefix, but none occurred")
+	}
+}
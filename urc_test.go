@@ -0,0 +1,49 @@
+package atmodem
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseNetworkRegistration(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		reg  NetworkRegistration
+	}{
+		{
+			name: "stat only",
+			raw:  "+CREG: 1",
+			reg:  NetworkRegistration{Stat: 1},
+		},
+		{
+			name: "full LTE",
+			raw:  `+CEREG: 5,"1A2B","0C3D44E",7`,
+			reg: NetworkRegistration{
+				Stat: 5,
+				LAC:  "1A2B",
+				CI:   "0C3D44E",
+				AcT:  7,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := parseNetworkRegistration(URC{Raw: tt.raw})
+			if diff := cmp.Diff(tt.reg, reg); diff != "" {
+				t.Fatalf("unexpected registration (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_parseSMSNotification(t *testing.T) {
+	n := parseSMSNotification(URC{Raw: `+CMTI: "ME",4`})
+
+	want := SMSNotification{Memory: "ME", Index: 4}
+	if diff := cmp.Diff(want, n); diff != "" {
+		t.Fatalf("unexpected notification (-want +got):\n%s", diff)
+	}
+}
@@ -2,6 +2,7 @@ package atmodem
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -34,14 +35,25 @@ func newValueParser(ss []string) (*valueParser, error) {
 func (vp *valueParser) Err() error { return vp.err }
 
 // Int parses the input as an integer.
-func (vp *valueParser) Int() int {
+func (vp *valueParser) Int() int { return vp.IntAt(0) }
+
+// String parses the input as a string with each slice value joined by spaces.
+func (vp *valueParser) String() string {
+	if vp.err != nil {
+		return ""
+	}
+
+	return strings.Join(vp.ss, " ")
+}
+
+// Float64 parses the input as a floating point number.
+func (vp *valueParser) Float64() float64 {
 	if vp.err != nil {
 		return 0
 	}
 
 	// This access is safe due to the constructor bounds check.
-	// TODO: parameterize the index?
-	v, err := strconv.Atoi(vp.ss[0])
+	v, err := strconv.ParseFloat(vp.ss[0], 64)
 	if err != nil {
 		vp.err = err
 		return 0
@@ -50,11 +62,63 @@ func (vp *valueParser) Int() int {
 	return v
 }
 
-// String parses the input as a string with each slice value joined by spaces.
-func (vp *valueParser) String() string {
+// at returns the raw value at index i, setting vp.err if i is out of range.
+func (vp *valueParser) at(i int) string {
 	if vp.err != nil {
 		return ""
 	}
 
-	return strings.Join(vp.ss, " ")
+	if i < 0 || i >= len(vp.ss) {
+		vp.err = fmt.Errorf("atmodem: no value at index %d", i)
+		return ""
+	}
+
+	return vp.ss[i]
+}
+
+// IntAt parses the value at index i as an integer.
+func (vp *valueParser) IntAt(i int) int {
+	s := vp.at(i)
+	if vp.err != nil {
+		return 0
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		vp.err = err
+		return 0
+	}
+
+	return v
+}
+
+// StringAt returns the value at index i as-is.
+func (vp *valueParser) StringAt(i int) string { return vp.at(i) }
+
+// QuotedStringAt parses the value at index i as a string, stripping a
+// leading and trailing double quote if present.
+func (vp *valueParser) QuotedStringAt(i int) string {
+	s := vp.at(i)
+	if vp.err != nil {
+		return ""
+	}
+
+	return strings.Trim(s, `"`)
+}
+
+// splitFields splits the comma-separated fields following the first colon in
+// an AT command response line, trimming surrounding whitespace. It returns an
+// error if line contains no colon.
+func splitFields(line string) ([]string, error) {
+	_, rest, ok := strings.Cut(line, ":")
+	if !ok {
+		return nil, fmt.Errorf("atmodem: malformed response line: %q", line)
+	}
+
+	fields := strings.Split(rest, ",")
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+
+	return fields, nil
 }